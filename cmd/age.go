@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/age"
+	"github.com/spf13/cobra"
+)
+
+// ageOptions holds the flags used to select resources by age.
+type ageOptions struct {
+	olderThan time.Duration
+	newerThan time.Duration
+	missing   string
+}
+
+// addAgeFlags registers the flags used to select resources by how long ago
+// they were created.
+func addAgeFlags(cmd *cobra.Command, opts *ageOptions) {
+	cmd.Flags().DurationVar(&opts.olderThan, "older-than", 0, `only select resources created more than this long ago, like "6h"`)
+	cmd.Flags().DurationVar(&opts.newerThan, "newer-than", 0, `only select resources created less than this long ago, like "30m"`)
+	cmd.Flags().StringVar(&opts.missing, "missing-created-at", string(age.MissingSkip), `what to do with resources without a creation time when filtering by age: "skip" or "include"`)
+}
+
+// newAgeFilter creates the age filter from the age flags.
+func newAgeFilter(opts ageOptions) (*age.Filter, error) {
+	missing, err := age.ParseMissingPolicy(opts.missing)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for --missing-created-at: %w", err)
+	}
+
+	filter, err := age.New(opts.olderThan, opts.newerThan, missing)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create age filter: %w", err)
+	}
+
+	return filter, nil
+}
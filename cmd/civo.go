@@ -5,20 +5,46 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/konstructio/dropkick/internal/civo"
-	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/protect"
+	"github.com/konstructio/dropkick/internal/report"
+	"github.com/konstructio/dropkick/internal/tags"
+	"github.com/konstructio/dropkick/pkg/env"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 type civoOptions struct {
-	nuke        bool
-	region      string
-	nameFilter  string
-	quiet       bool
-	onlyOrphans bool
+	nuke         bool
+	region       string
+	names        nameOptions
+	age          ageOptions
+	logs         logOptions
+	onlyOrphans  bool
+	keepExtDNS   bool
+	expire       bool
+	keepGoing    bool
+	skipGlobal   bool // set for all but one region of a run, since DNS domains belong to the whole account
+	interactive  bool
+	output       string
+	recorder     *report.Recorder
+	concurrency  int
+	waitTimeout  time.Duration
+	waitInterval time.Duration
+	maxRetries   int
+	retryDelay   time.Duration
+	maxRPS       float64
+	protectFile  string
+	profileRules *protect.Rules
+	only         []string
+	tags         []string
+	withoutTags  []string
+	skip         []string
 }
 
 func getCivoCommand() *cobra.Command {
@@ -29,45 +55,309 @@ func getCivoCommand() *cobra.Command {
 		Short: "clean civo resources",
 		Long:  `clean civo resources`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			opts.quiet = cmd.Flags().Lookup("quiet").Value.String() == "true"
-			return runCivo(cmd.Context(), cmd.OutOrStderr(), opts, os.Getenv("CIVO_TOKEN"))
+			token, err := loadCivoSettings(cmd, &opts)
+			if err != nil {
+				return err
+			}
+
+			if opts.interactive {
+				return runCivoInteractive(cmd.Context(), cmd.OutOrStderr(), cmd.OutOrStdout(), cmd.InOrStdin(), opts, token)
+			}
+
+			return runCivo(cmd.Context(), cmd.OutOrStderr(), cmd.OutOrStdout(), opts, token)
 		},
 	}
 
 	civoCmd.Flags().BoolVar(&opts.nuke, "nuke", false, "required to confirm deletion of resources")
-	civoCmd.Flags().StringVar(&opts.region, "region", "", "the civo region to clean")
-	civoCmd.Flags().StringVar(&opts.nameFilter, "name-contains", "", "if set, only resources with a name containing this string will be selected")
-	civoCmd.Flags().BoolVar(&opts.onlyOrphans, "orphans-only", false, "only delete orphaned resources (only load balancers, volumes, object store credentials, SSH keys, networks and firewalls)")
+	civoCmd.Flags().BoolVar(&opts.interactive, "interactive", false, "list the resources that would be deleted and only delete the ones confirmed (with --nuke, answers can be read from a non-interactive stdin)")
+	civoCmd.Flags().IntVar(&opts.concurrency, "concurrency", 1, "the maximum number of resources deleted at the same time")
+	addOutputFlag(civoCmd, &opts.output)
+	civoCmd.Flags().BoolVar(&opts.keepGoing, "keep-going", false, "keep deleting the remaining resources when one fails, and report every failure at the end")
+	addCivoSelectionFlags(civoCmd, &opts)
+	addCivoWaitFlags(civoCmd, &opts)
+	addCivoAPIFlags(civoCmd, &opts)
+
+	civoCmd.AddCommand(getCivoPlanCommand())
+	civoCmd.AddCommand(getCivoApplyCommand())
+	civoCmd.AddCommand(getCivoExpireCommand())
+
+	return civoCmd
+}
+
+// addCivoSelectionFlags registers the flags used to select which Civo
+// resources are processed. They're shared by every command that walks
+// the Civo account.
+func addCivoSelectionFlags(cmd *cobra.Command, opts *civoOptions) {
+	cmd.Flags().StringVar(&opts.region, "region", "", `the civo region to clean: a single region, a comma-separated list of regions, or "all" for every region`)
+	addNameFlags(cmd, &opts.names)
+	addAgeFlags(cmd, &opts.age)
+	cmd.Flags().StringVar(&opts.protectFile, "protect-file", "", "a YAML file listing resource IDs, name globs, name regexes and resource types that must never be deleted")
+	cmd.Flags().StringSliceVar(&opts.only, "only", nil, `only process these resource types, like "volumes,sshkeys"`)
+	cmd.Flags().StringSliceVar(&opts.skip, "skip", nil, `never process these resource types, like "objectstores"`)
+	cmd.Flags().StringArrayVar(&opts.tags, "tag", nil, `only select resources with this tag, like "env=ci", or with this tag key, like "ephemeral" (repeatable, every tag must match, only instances and kubernetes clusters support tags)`)
+	cmd.Flags().StringArrayVar(&opts.withoutTags, "without-tag", nil, `never select resources with this tag, like "keep=true", or with this tag key (repeatable)`)
+	cmd.Flags().BoolVar(&opts.onlyOrphans, "orphans-only", false, "only delete orphaned resources (only load balancers, volumes, reserved IPs, snapshots, object store credentials, SSH keys, networks, firewalls and DNS records)")
+	cmd.Flags().BoolVar(&opts.keepExtDNS, "keep-external-dns-records", false, "with --orphans-only, keep the DNS records pointing to addresses no civo instance or load balancer uses, for records pointing outside civo")
 
-	if err := civoCmd.MarkFlagRequired("region"); err != nil {
+	if err := cmd.MarkFlagRequired("region"); err != nil {
 		log.Fatal(err)
 	}
+}
 
-	return civoCmd
+// addCivoAPIFlags registers the flags used to configure how dropkick talks
+// to the Civo API.
+func addCivoAPIFlags(cmd *cobra.Command, opts *civoOptions) {
+	cmd.Flags().IntVar(&opts.maxRetries, "max-retries", 3, "how many times failed requests to the civo API are retried")
+	cmd.Flags().DurationVar(&opts.retryDelay, "retry-delay", time.Second, "the delay before retrying a failed request to the civo API, doubling on every retry")
+	cmd.Flags().Float64Var(&opts.maxRPS, "max-rps", 0, "the maximum number of requests per second sent to the civo API (0 means no limit)")
+}
+
+// addCivoWaitFlags registers the flags used to configure how long to wait
+// for deleted Civo resources to be gone before deleting their dependencies.
+func addCivoWaitFlags(cmd *cobra.Command, opts *civoOptions) {
+	cmd.Flags().DurationVar(&opts.waitTimeout, "wait-timeout", 5*time.Minute, "how long to wait for a deleted resource to be gone before deleting the resources depending on it (0 disables waiting)")
+	cmd.Flags().DurationVar(&opts.waitInterval, "wait-interval", 2*time.Second, "how long to wait before checking if a deleted resource is gone, doubling after every check")
+}
+
+// loadCivoSettings reads the log options and the protect rules of the
+// profile into the command options, and returns the Civo token from
+// $CIVO_TOKEN or, if it's not set, from the profile.
+func loadCivoSettings(cmd *cobra.Command, opts *civoOptions) (string, error) {
+	opts.logs = getLogOptions(cmd)
+
+	profile := profileFrom(cmd)
+
+	rules, err := profile.ProtectRules()
+	if err != nil {
+		return "", err //nolint:wrapcheck // the error is already wrapped
+	}
+
+	opts.profileRules = rules
+
+	if token := env.GetFirstNotEmpty("CIVO_TOKEN"); token != "" || profile == nil {
+		return token, nil
+	}
+
+	token, err := profile.Token.Resolve()
+	if err != nil {
+		return "", fmt.Errorf("unable to read the civo token of the profile: %w", err)
+	}
+
+	return token, nil
 }
 
-func runCivo(ctx context.Context, output io.Writer, opts civoOptions, token string) error {
+// errCivoTokenMissing is returned when no Civo token is available.
+var errCivoTokenMissing = errors.New("required environment variable $CIVO_TOKEN not found: get one at https://dashboard.civo.com/security")
+
+// newCivoClient validates the token and creates a Civo client with a logger
+// writing to output, configured by the log options.
+func newCivoClient(output io.Writer, opts civoOptions, token string) (*civo.Civo, error) {
 	if token == "" {
-		return errors.New("required environment variable $CIVO_TOKEN not found: get one at https://dashboard.civo.com/security")
+		return nil, errCivoTokenMissing
+	}
+
+	clientOpts, err := civoClientOptions(output, opts, token)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := civo.New(clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create new client: %w", err)
+	}
+
+	return client, nil
+}
+
+// civoClientOptions converts the command options into Civo client options.
+func civoClientOptions(output io.Writer, opts civoOptions, token string) ([]civo.Option, error) {
+	rules, err := loadProtectFile(opts.protectFile, opts.profileRules)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := newNameMatcher(opts.names)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create a logger and make it quiet
-	var log *logger.Logger
-	if opts.quiet {
-		log = logger.New(io.Discard)
-	} else {
-		log = logger.New(output)
+	filter, err := newAgeFilter(opts.age)
+	if err != nil {
+		return nil, err
+	}
+
+	tagFilter, err := tags.NewFilter(opts.tags, opts.withoutTags)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create tag filter: %w", err)
+	}
+
+	log, err := newLogger(output, opts.logs)
+	if err != nil {
+		return nil, err
 	}
 
-	client, err := civo.New(
+	return []civo.Option{
 		civo.WithToken(token),
 		civo.WithRegion(opts.region),
-		civo.WithNameFilter(opts.nameFilter),
+		civo.WithNameMatcher(names),
+		civo.WithAgeFilter(filter),
+		civo.WithTagFilter(tagFilter),
 		civo.WithNuke(opts.nuke),
 		civo.WithLogger(log),
-	)
+		civo.WithConcurrency(opts.concurrency),
+		civo.WithWaitForDeletion(opts.waitTimeout, opts.waitInterval),
+		civo.WithRetries(opts.maxRetries, opts.retryDelay),
+		civo.WithMaxRPS(opts.maxRPS),
+		civo.WithProtect(rules),
+		civo.WithResourceTypes(opts.only, opts.skip),
+		civo.WithKeepExternalDNSRecords(opts.keepExtDNS),
+		civo.WithGlobalResources(!opts.skipGlobal),
+		civo.WithKeepGoing(opts.keepGoing),
+		civo.WithRecorder(opts.recorder),
+	}, nil
+}
+
+// resolveCivoRegions expands the region option into the list of regions to
+// process. The region can be a single region, a comma-separated list of
+// regions, or "all" to process every region available to the account.
+func resolveCivoRegions(ctx context.Context, output io.Writer, opts civoOptions, token string) ([]string, error) {
+	if strings.EqualFold(strings.TrimSpace(opts.region), "all") {
+		clientOpts, err := civoClientOptions(output, opts, token)
+		if err != nil {
+			return nil, err
+		}
+
+		regions, err := civo.ListRegions(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to discover regions: %w", err)
+		}
+
+		if len(regions) == 0 {
+			return nil, errors.New("no regions found for the civo account")
+		}
+
+		return regions, nil
+	}
+
+	var regions []string
+	for _, region := range strings.Split(opts.region, ",") {
+		region = strings.TrimSpace(region)
+		if region == "" {
+			continue
+		}
+
+		if strings.EqualFold(region, "all") {
+			return nil, errors.New(`region "all" can't be combined with other regions`)
+		}
+
+		if !slices.Contains(regions, region) {
+			regions = append(regions, region)
+		}
+	}
+
+	if len(regions) == 0 {
+		return nil, errors.New("at least one region is required")
+	}
+
+	return regions, nil
+}
+
+// regionResult is the outcome of processing a single region.
+type regionResult struct {
+	region string
+	err    error
+}
+
+// runCivo processes every region selected by the options, then prints a
+// summary per resource type and writes the structured output, if requested,
+// to stdout.
+func runCivo(ctx context.Context, output, stdout io.Writer, opts civoOptions, token string) error {
+	if token == "" {
+		return errCivoTokenMissing
+	}
+
+	format, recorder, err := newRecorder(opts.output, output)
+	if err != nil {
+		return err
+	}
+
+	opts.recorder = recorder
+	runErr := runCivoRegions(ctx, output, opts, token)
+
+	return errors.Join(runErr, writeReport(output, stdout, format, recorder, opts.logs.quiet))
+}
+
+// runCivoRegions processes every region selected by the options, one after
+// the other. A failure in one region doesn't stop the others.
+func runCivoRegions(ctx context.Context, output io.Writer, opts civoOptions, token string) error {
+	regions, err := resolveCivoRegions(ctx, output, opts, token)
 	if err != nil {
-		return fmt.Errorf("unable to create new client: %w", err)
+		return err
+	}
+
+	if len(regions) == 1 {
+		opts.region = regions[0]
+		return runCivoRegion(ctx, output, opts, token)
+	}
+
+	// A failure in one region doesn't stop the others from being processed.
+	// DNS domains belong to the whole account, so they're only processed
+	// with the first region.
+	results := make([]regionResult, 0, len(regions))
+	for i, region := range regions {
+		if ctx.Err() != nil {
+			break
+		}
+
+		opts.region = region
+		opts.skipGlobal = i > 0
+		results = append(results, regionResult{
+			region: region,
+			err:    runCivoRegion(ctx, output, opts, token),
+		})
+	}
+
+	printRegionSummary(output, results)
+
+	var errs []error
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("region %q: %w", res.region, res.err))
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// printRegionSummary writes a table with the outcome of every region.
+func printRegionSummary(output io.Writer, results []regionResult) {
+	w := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REGION\tRESULT")
+
+	for _, res := range results {
+		result := "ok"
+		if res.err != nil {
+			result = "failed: " + res.err.Error()
+		}
+
+		fmt.Fprintf(w, "%s\t%s\n", res.region, result)
+	}
+
+	w.Flush()
+}
+
+func runCivoRegion(ctx context.Context, output io.Writer, opts civoOptions, token string) error {
+	client, err := newCivoClient(output, opts, token)
+	if err != nil {
+		return err
+	}
+
+	if opts.expire {
+		return client.Expire(ctx) //nolint:wrapcheck // the error is already wrapped
 	}
 
 	if opts.onlyOrphans {
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+func getCivoExpireCommand() *cobra.Command {
+	opts := civoOptions{expire: true}
+
+	cmd := &cobra.Command{
+		Use:   "expire",
+		Short: "delete the civo resources whose expiry tag says they have expired",
+		Long: `delete the civo resources tagged with "dropkick-ttl=<duration>", like
+"dropkick-ttl=4h", once that long has passed since their creation, or
+tagged with "dropkick-expires=<time>", like "dropkick-expires=2026-10-20T00:00Z",
+once that time has passed. Resources without one of those tags are left
+alone, so it can safely run on a schedule against a shared account.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.onlyOrphans {
+				return errors.New("--orphans-only can't be used when expiring resources")
+			}
+
+			token, err := loadCivoSettings(cmd, &opts)
+			if err != nil {
+				return err
+			}
+
+			return runCivo(cmd.Context(), cmd.OutOrStderr(), cmd.OutOrStdout(), opts, token)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.nuke, "nuke", false, "required to confirm deletion of expired resources")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 1, "the maximum number of resources deleted at the same time")
+	addOutputFlag(cmd, &opts.output)
+	cmd.Flags().BoolVar(&opts.keepGoing, "keep-going", false, "keep deleting the remaining resources when one fails, and report every failure at the end")
+	addCivoSelectionFlags(cmd, &opts)
+	addCivoWaitFlags(cmd, &opts)
+	addCivoAPIFlags(cmd, &opts)
+
+	return cmd
+}
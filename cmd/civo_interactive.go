@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/konstructio/dropkick/internal/prompt"
+	"github.com/mattn/go-isatty"
+)
+
+// errNotInteractive is returned when --interactive is used without a
+// terminal on stdin and without --nuke confirming that the answers read
+// from stdin may delete resources.
+var errNotInteractive = errors.New("refusing to run interactively: stdin is not a terminal, set --nuke to confirm that answers read from stdin may delete resources")
+
+// isTerminal reports whether the given reader or writer is a terminal.
+func isTerminal(v any) bool {
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// runCivoInteractive plans the deletion of the Civo resources selected by
+// the options, asks the operator which of them to delete, and deletes only
+// those. On a terminal the planned resources are listed in a checkbox list,
+// otherwise the operator is asked about each of them in turn.
+func runCivoInteractive(ctx context.Context, output, stdout io.Writer, in io.Reader, opts civoOptions, token string) error {
+	if strings.Contains(opts.region, ",") || strings.EqualFold(strings.TrimSpace(opts.region), "all") {
+		return errors.New("interactive mode only supports a single region")
+	}
+
+	stdinTerminal := isTerminal(in)
+	if !stdinTerminal && !opts.nuke {
+		return errNotInteractive
+	}
+
+	format, recorder, err := newRecorder(opts.output, output)
+	if err != nil {
+		return err
+	}
+
+	// Planning records nothing: only what the operator confirms is
+	// reported, once it's deleted.
+	client, err := newCivoClient(output, opts, token)
+	if err != nil {
+		return err
+	}
+
+	plan, err := client.Plan(ctx, opts.onlyOrphans)
+	if err != nil {
+		return fmt.Errorf("unable to find resources to delete: %w", err)
+	}
+
+	if len(plan.Resources) == 0 {
+		fmt.Fprintln(output, "no resources to delete")
+		return writeReport(output, stdout, format, recorder, opts.logs.quiet)
+	}
+
+	items := make([]prompt.Item, 0, len(plan.Resources))
+	for i, planned := range plan.Resources {
+		items = append(items, prompt.Item{
+			Group:  planned.Type,
+			Label:  fmt.Sprintf("%q (ID: %q)", planned.Name, planned.ID),
+			Blocks: plan.Blocks(i),
+		})
+	}
+
+	var selected []int
+	if stdinTerminal && isTerminal(output) {
+		selected, err = checklist(in, output, items)
+	} else {
+		selected, err = prompt.Confirm(in, output, items)
+	}
+
+	if err != nil {
+		return err //nolint:wrapcheck // the error is already wrapped
+	}
+
+	if len(selected) == 0 {
+		fmt.Fprintln(output, "no resources selected, nothing was deleted")
+		return writeReport(output, stdout, format, recorder, opts.logs.quiet)
+	}
+
+	// The operator's confirmation is the confirmation to delete.
+	opts.nuke = true
+	opts.recorder = recorder
+
+	client, err = newCivoClient(output, opts, token)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ApplyPlan(ctx, plan.Subset(selected)); err != nil {
+		return errors.Join(fmt.Errorf("unable to delete the selected resources: %w", err), writeReport(output, stdout, format, recorder, opts.logs.quiet))
+	}
+
+	return writeReport(output, stdout, format, recorder, opts.logs.quiet)
+}
+
+// checklist lets the operator tick the items to delete in a checkbox list
+// drawn on the terminal, or asks about each of them in turn if the terminal
+// can't be put in raw mode.
+func checklist(in io.Reader, output io.Writer, items []prompt.Item) ([]int, error) {
+	f, _ := in.(*os.File)
+
+	screen, restore, err := prompt.MakeRaw(f)
+	if err != nil {
+		fmt.Fprintf(output, "%s, asking about each resource instead\n", err)
+		return prompt.Confirm(in, output, items) //nolint:wrapcheck // the error is already wrapped
+	}
+
+	selected, err := prompt.Checklist(in, output, screen, items)
+	return selected, errors.Join(err, restore())
+}
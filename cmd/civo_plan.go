@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/konstructio/dropkick/internal/civo"
+	"github.com/spf13/cobra"
+)
+
+func getCivoPlanCommand() *cobra.Command {
+	var (
+		opts    = civoOptions{concurrency: 1}
+		outFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "record the civo resources that would be deleted into a plan file",
+		Long: `record the civo resources that would be deleted into a plan file, so it
+can be reviewed and later applied with "dropkick civo apply"`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			token, err := loadCivoSettings(cmd, &opts)
+			if err != nil {
+				return err
+			}
+
+			return runCivoPlan(cmd.Context(), cmd.OutOrStderr(), cmd.OutOrStdout(), opts, outFile, token)
+		},
+	}
+
+	addCivoSelectionFlags(cmd, &opts)
+	addCivoAPIFlags(cmd, &opts)
+	cmd.Flags().StringVar(&outFile, "out", "", "the file to write the plan to (defaults to stdout)")
+
+	return cmd
+}
+
+func runCivoPlan(ctx context.Context, output, stdout io.Writer, opts civoOptions, outFile, token string) error {
+	if strings.Contains(opts.region, ",") || strings.EqualFold(strings.TrimSpace(opts.region), "all") {
+		return errors.New("a plan can only be created for a single region")
+	}
+
+	client, err := newCivoClient(output, opts, token)
+	if err != nil {
+		return err
+	}
+
+	plan, err := client.Plan(ctx, opts.onlyOrphans)
+	if err != nil {
+		return fmt.Errorf("unable to create plan: %w", err)
+	}
+
+	if outFile == "" {
+		return civo.WritePlan(stdout, plan) //nolint:wrapcheck // the error is already wrapped
+	}
+
+	f, err := os.Create(outFile)
+	if err != nil {
+		return fmt.Errorf("unable to create plan file %q: %w", outFile, err)
+	}
+
+	if err := civo.WritePlan(f, plan); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write plan file %q: %w", outFile, err)
+	}
+
+	// The plan is only written once the file is closed, so a failure here
+	// means the plan file might be truncated.
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to write plan file %q: %w", outFile, err)
+	}
+
+	return nil
+}
+
+func getCivoApplyCommand() *cobra.Command {
+	// Planned resources are deleted one by one, in the order they were
+	// recorded, so the dependency order is kept.
+	opts := civoOptions{concurrency: 1}
+
+	cmd := &cobra.Command{
+		Use:   "apply <plan-file>",
+		Short: "delete exactly the civo resources recorded in a plan file",
+		Long: `delete exactly the civo resources recorded in a plan file created with
+"dropkick civo plan". If any planned resource has changed or disappeared
+since the plan was created, or is no longer orphaned for plans created with
+--orphans-only, nothing is deleted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := loadCivoSettings(cmd, &opts)
+			if err != nil {
+				return err
+			}
+
+			return runCivoApply(cmd.Context(), cmd.OutOrStderr(), cmd.OutOrStdout(), opts, args[0], token)
+		},
+	}
+
+	addCivoWaitFlags(cmd, &opts)
+	addOutputFlag(cmd, &opts.output)
+	cmd.Flags().BoolVar(&opts.keepGoing, "keep-going", false, "keep deleting the remaining resources when one fails, and report every failure at the end")
+	addCivoAPIFlags(cmd, &opts)
+	cmd.Flags().StringVar(&opts.protectFile, "protect-file", "", "a YAML file listing resource IDs, name globs, name regexes and resource types that must never be deleted")
+
+	return cmd
+}
+
+func runCivoApply(ctx context.Context, output, stdout io.Writer, opts civoOptions, planFile, token string) error {
+	f, err := os.Open(planFile)
+	if err != nil {
+		return fmt.Errorf("unable to open plan file %q: %w", planFile, err)
+	}
+	defer f.Close()
+
+	plan, err := civo.ReadPlan(f)
+	if err != nil {
+		return fmt.Errorf("unable to read plan file %q: %w", planFile, err)
+	}
+
+	// The plan is the confirmation, so the client is allowed to delete
+	// and targets the region the plan was created for.
+	opts.region = plan.Region
+	opts.nuke = true
+
+	format, recorder, err := newRecorder(opts.output, output)
+	if err != nil {
+		return err
+	}
+
+	opts.recorder = recorder
+
+	client, err := newCivoClient(output, opts, token)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ApplyPlan(ctx, plan); err != nil {
+		return errors.Join(fmt.Errorf("unable to apply plan: %w", err), writeReport(output, stdout, format, recorder, opts.logs.quiet))
+	}
+
+	return writeReport(output, stdout, format, recorder, opts.logs.quiet)
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/konstructio/dropkick/internal/config"
+	"github.com/konstructio/dropkick/pkg/env"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// profileKey is the context key of the profile selected for a command.
+type profileKey struct{}
+
+// addConfigFlags registers the global flags used to select a profile from
+// the configuration file.
+func addConfigFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("config", "", "the configuration file with the profiles (defaults to ~/.config/dropkick/config.yaml)")
+	cmd.PersistentFlags().String("profile", "", "the profile of the configuration file to use, overriding $DROPKICK_PROFILE and the default profile")
+}
+
+// commandProvider returns the provider a command works with, from the name
+// of its top-level command, or an empty string if it doesn't work with a
+// provider.
+func commandProvider(cmd *cobra.Command) string {
+	switch name := topLevelCommand(cmd).Name(); name {
+	case config.ProviderCivo, config.ProviderDigitalOcean:
+		return name
+	default:
+		return ""
+	}
+}
+
+// topLevelCommand returns the command right under the root command that the
+// given command belongs to, or the command itself if it's the root command.
+func topLevelCommand(cmd *cobra.Command) *cobra.Command {
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+
+	return cmd
+}
+
+// selectProfile finds the profile selected by the --config and --profile
+// flags, $DROPKICK_PROFILE or the default profile. It returns the name of
+// the configuration file, the name of the profile, and the profile, which
+// is nil if none was selected. A missing configuration file is only an
+// error if a profile was explicitly selected.
+func selectProfile(cmd *cobra.Command) (string, string, *config.Profile, error) {
+	filename := cmd.Flags().Lookup("config").Value.String()
+	explicitFile := filename != ""
+
+	if !explicitFile {
+		var err error
+		if filename, err = config.DefaultPath(); err != nil {
+			return "", "", nil, err //nolint:wrapcheck // the error is already wrapped
+		}
+	}
+
+	name := cmd.Flags().Lookup("profile").Value.String()
+	if name == "" {
+		name = strings.TrimSpace(os.Getenv("DROPKICK_PROFILE"))
+	}
+
+	cfg, err := config.Load(filename)
+	if errors.Is(err, fs.ErrNotExist) && !explicitFile && name == "" {
+		return filename, "", nil, nil
+	}
+
+	if err != nil {
+		return "", "", nil, err //nolint:wrapcheck // the error is already wrapped
+	}
+
+	profile, err := cfg.Profile(name)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("unable to select profile: %w", err)
+	}
+
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+
+	return filename, name, profile, nil
+}
+
+// loadProfile selects the profile for a command working with a provider,
+// sets the flags the operator didn't set from the profile, and keeps the
+// profile in the command context for the credentials. The default profile
+// is ignored when it's for another provider.
+func loadProfile(cmd *cobra.Command, _ []string) error {
+	provider := commandProvider(cmd)
+	if provider == "" {
+		return nil
+	}
+
+	_, name, profile, err := selectProfile(cmd)
+	if err != nil || profile == nil {
+		return err
+	}
+
+	if profile.Provider != provider {
+		if cmd.Flags().Changed("profile") || os.Getenv("DROPKICK_PROFILE") != "" {
+			return fmt.Errorf("profile %q is for provider %q, not %q", name, profile.Provider, provider)
+		}
+
+		return nil
+	}
+
+	if err := applyProfile(cmd, profile); err != nil {
+		return fmt.Errorf("unable to apply profile %q: %w", name, err)
+	}
+
+	cmd.SetContext(context.WithValue(cmd.Context(), profileKey{}, profile))
+	return nil
+}
+
+// profileFrom returns the profile selected for a command, or nil if none
+// was selected.
+func profileFrom(cmd *cobra.Command) *config.Profile {
+	profile, _ := cmd.Context().Value(profileKey{}).(*config.Profile)
+	return profile
+}
+
+// applyProfile sets the flags of a command from the profile, so flags set
+// by the operator take precedence. It returns an error naming the setting if
+// the provider command has no flag for it. Settings for flags only a
+// subcommand lacks, like the filters for "civo apply", are ignored.
+func applyProfile(cmd *cobra.Command, profile *config.Profile) error {
+	f := profile.Filters
+
+	values := []struct {
+		key    string
+		flag   string
+		values []string
+	}{
+		{"regions", "region", joined(profile.Regions)},
+		{"filters.name_contains", "name-contains", f.NameContains},
+		{"filters.name_prefixes", "name-prefix", f.NamePrefixes},
+		{"filters.name_globs", "name-glob", f.NameGlobs},
+		{"filters.name_regexes", "name-regex", f.NameRegexes},
+		{"filters.name_not_contains", "name-not-contains", f.NameNotContains},
+		{"filters.name_not_prefixes", "name-not-prefix", f.NameNotPrefixes},
+		{"filters.name_not_globs", "name-not-glob", f.NameNotGlobs},
+		{"filters.name_not_regexes", "name-not-regex", f.NameNotRegexes},
+		{"filters.name_match", "name-match", single(f.NameMatch)},
+		{"filters.older_than", "older-than", single(f.OlderThan)},
+		{"filters.newer_than", "newer-than", single(f.NewerThan)},
+		{"filters.missing_created_at", "missing-created-at", single(f.MissingCreatedAt)},
+		{"filters.tags", "tag", f.Tags},
+		{"filters.without_tags", "without-tag", f.WithoutTags},
+		{"filters.only", "only", f.Only},
+		{"filters.skip", "skip", f.Skip},
+	}
+
+	provider := topLevelCommand(cmd)
+
+	for _, v := range values {
+		if len(v.values) == 0 {
+			continue
+		}
+
+		if provider.Flags().Lookup(v.flag) == nil {
+			return fmt.Errorf("setting %q is not supported by provider %q", v.key, profile.Provider)
+		}
+
+		flag := cmd.Flags().Lookup(v.flag)
+		if flag == nil || flag.Changed {
+			continue
+		}
+
+		for _, value := range v.values {
+			if err := cmd.Flags().Set(v.flag, value); err != nil {
+				return fmt.Errorf("invalid %s %q: %w", v.flag, value, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// single returns a list with the value, or an empty list if it's empty.
+func single(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return []string{value}
+}
+
+// joined returns a list with the values joined by commas, or an empty list
+// if there are no values.
+func joined(values []string) []string {
+	return single(strings.Join(values, ","))
+}
+
+// secretFromEnv returns the secret read from the first of the environment
+// variables that is set, or the fallback if none is.
+func secretFromEnv(fallback *config.Secret, keys ...string) *config.Secret {
+	for _, key := range keys {
+		if env.GetFirstNotEmpty(key) != "" {
+			return &config.Secret{Env: key}
+		}
+	}
+
+	return fallback
+}
+
+func getConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "inspect the dropkick configuration file",
+		Long:  `inspect the dropkick configuration file and its profiles`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "view",
+		Short: "show the settings of the selected profile and the environment, with secrets redacted",
+		Long: `show the settings of the profile selected with --profile, $DROPKICK_PROFILE or
+the default profile, after applying the environment variables that take
+precedence over it. Command-line flags aren't applied: the flags given to a
+provider command still take precedence over what's shown. Secrets are
+redacted, but the environment variables and files they're read from are
+shown.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runConfigView(cmd, cmd.OutOrStdout())
+		},
+	})
+
+	return cmd
+}
+
+// configView is what "dropkick config view" shows. The settings come from
+// the profile and the environment only, since the flags belong to the
+// provider commands.
+type configView struct {
+	ConfigFile string          `yaml:"config_file"`
+	Profile    string          `yaml:"profile"`
+	Settings   *config.Profile `yaml:"profile_and_environment"`
+}
+
+func runConfigView(cmd *cobra.Command, stdout io.Writer) error {
+	filename, name, profile, err := selectProfile(cmd)
+	if err != nil {
+		return err
+	}
+
+	if profile == nil {
+		return fmt.Errorf("no profile selected from %q: use --profile, $DROPKICK_PROFILE or set default_profile", filename)
+	}
+
+	// Resolve the settings in the same way the provider commands do, where
+	// environment variables take precedence over the profile.
+	settings := *profile
+
+	switch settings.Provider {
+	case config.ProviderCivo:
+		settings.Token = secretFromEnv(settings.Token, "CIVO_TOKEN")
+	case config.ProviderDigitalOcean:
+		settings.Token = secretFromEnv(settings.Token, "DIGITALOCEAN_TOKEN")
+
+		spaces := config.Spaces{}
+		if settings.Spaces != nil {
+			spaces = *settings.Spaces
+		}
+
+		spaces.AccessKey = secretFromEnv(spaces.AccessKey, "DIGITALOCEAN_SPACES_ACCESS_KEY", "SPACES_KEY")
+		spaces.SecretKey = secretFromEnv(spaces.SecretKey, "DIGITALOCEAN_SPACES_SECRET_KEY", "SPACES_SECRET")
+		if region := env.GetFirstNotEmpty("DIGITALOCEAN_SPACES_REGION", "SPACES_REGION"); region != "" {
+			spaces.Region = region
+		}
+
+		settings.Spaces = &spaces
+	}
+
+	out, err := yaml.Marshal(configView{ConfigFile: filename, Profile: name, Settings: &settings})
+	if err != nil {
+		return fmt.Errorf("unable to encode settings: %w", err)
+	}
+
+	_, err = stdout.Write(out)
+	return err //nolint:wrapcheck // the error comes straight from the writer
+}
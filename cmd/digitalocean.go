@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/konstructio/dropkick/internal/config"
 	"github.com/konstructio/dropkick/internal/digitalocean"
-	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/protect"
+	"github.com/konstructio/dropkick/internal/report"
 	"github.com/konstructio/dropkick/pkg/env"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +20,12 @@ type doOptions struct {
 	spacesAccessKey string
 	spacesSecretKey string
 	spacesRegion    string
+	protectFile     string
+	profileRules    *protect.Rules
+	names           nameOptions
+	age             ageOptions
+	output          string
+	recorder        *report.Recorder
 }
 
 func getDigitalOceanCommand() *cobra.Command {
@@ -28,42 +36,195 @@ func getDigitalOceanCommand() *cobra.Command {
 		Short: "clean digitalocean resources",
 		Long:  `clean digitalocean resources`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			opts.token = env.GetFirstNotEmpty("DIGITALOCEAN_TOKEN")
-			opts.spacesAccessKey = env.GetFirstNotEmpty("DIGITALOCEAN_SPACES_ACCESS_KEY", "SPACES_KEY")
-			opts.spacesSecretKey = env.GetFirstNotEmpty("DIGITALOCEAN_SPACES_SECRET_KEY", "SPACES_SECRET")
-			opts.spacesRegion = env.GetFirstNotEmpty("DIGITALOCEAN_SPACES_REGION", "SPACES_REGION")
-			quiet := cmd.Flags().Lookup("quiet").Value.String() == "true"
-			return runDigitalOcean(cmd.Context(), cmd.OutOrStderr(), opts, quiet)
+			if err := loadDigitalOceanSettings(cmd, &opts); err != nil {
+				return err
+			}
+
+			logs := getLogOptions(cmd)
+			return runDigitalOcean(cmd.Context(), cmd.OutOrStderr(), cmd.OutOrStdout(), opts, logs)
 		},
 	}
 
 	cmd.Flags().BoolVar(&opts.nuke, "nuke", false, "required to confirm deletion of resources")
+	addDigitalOceanSelectionFlags(cmd, &opts)
+
+	cmd.AddCommand(getDigitalOceanExpireCommand())
+	return cmd
+}
+
+func getDigitalOceanExpireCommand() *cobra.Command {
+	var opts doOptions
+
+	cmd := &cobra.Command{
+		Use:   "expire",
+		Short: "delete the digitalocean resources whose expiry tag says they have expired",
+		Long: `delete the digitalocean kubernetes clusters and volumes tagged with
+"dropkick-ttl:<duration>", like "dropkick-ttl:4h", once that long has passed
+since their creation, or tagged with "dropkick-expires:<time>", like
+"dropkick-expires:2026-10-20T00:00Z", once that time has passed. Resources
+without one of those tags are left alone.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := loadDigitalOceanSettings(cmd, &opts); err != nil {
+				return err
+			}
+
+			logs := getLogOptions(cmd)
+			return runDigitalOceanExpire(cmd.Context(), cmd.OutOrStderr(), cmd.OutOrStdout(), opts, logs)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.nuke, "nuke", false, "required to confirm deletion of expired resources")
+	addDigitalOceanSelectionFlags(cmd, &opts)
 	return cmd
 }
 
-func runDigitalOcean(ctx context.Context, output io.Writer, opts doOptions, quiet bool) error {
+// addDigitalOceanSelectionFlags registers the flags used to select which
+// DigitalOcean resources are processed.
+func addDigitalOceanSelectionFlags(cmd *cobra.Command, opts *doOptions) {
+	addNameFlags(cmd, &opts.names)
+	addAgeFlags(cmd, &opts.age)
+	addOutputFlag(cmd, &opts.output)
+	cmd.Flags().StringVar(&opts.protectFile, "protect-file", "", "a YAML file listing resource IDs, name globs, name regexes and resource types that must never be deleted")
+}
+
+// loadDigitalOceanSettings reads the DigitalOcean credentials from the
+// environment or, for those not set, from the profile, and the protect
+// rules of the profile.
+func loadDigitalOceanSettings(cmd *cobra.Command, opts *doOptions) error {
+	profile := profileFrom(cmd)
+
+	rules, err := profile.ProtectRules()
+	if err != nil {
+		return err //nolint:wrapcheck // the error is already wrapped
+	}
+
+	opts.profileRules = rules
+
+	var spaces config.Spaces
+	if profile != nil && profile.Spaces != nil {
+		spaces = *profile.Spaces
+	}
+
+	credentials := []struct {
+		value  *string
+		name   string
+		secret *config.Secret
+		keys   []string
+	}{
+		{&opts.token, "token", profileToken(profile), []string{"DIGITALOCEAN_TOKEN"}},
+		{&opts.spacesAccessKey, "spaces access key", spaces.AccessKey, []string{"DIGITALOCEAN_SPACES_ACCESS_KEY", "SPACES_KEY"}},
+		{&opts.spacesSecretKey, "spaces secret key", spaces.SecretKey, []string{"DIGITALOCEAN_SPACES_SECRET_KEY", "SPACES_SECRET"}},
+	}
+
+	for _, c := range credentials {
+		if *c.value = env.GetFirstNotEmpty(c.keys...); *c.value != "" {
+			continue
+		}
+
+		if *c.value, err = c.secret.Resolve(); err != nil {
+			return fmt.Errorf("unable to read the digitalocean %s of the profile: %w", c.name, err)
+		}
+	}
+
+	opts.spacesRegion = env.GetFirstNotEmpty("DIGITALOCEAN_SPACES_REGION", "SPACES_REGION")
+	if opts.spacesRegion == "" {
+		opts.spacesRegion = spaces.Region
+	}
+
+	return nil
+}
+
+// profileToken returns the token of the profile, or nil if there's no
+// profile.
+func profileToken(profile *config.Profile) *config.Secret {
+	if profile == nil {
+		return nil
+	}
+
+	return profile.Token
+}
+
+func runDigitalOcean(ctx context.Context, output, stdout io.Writer, opts doOptions, logs logOptions) error {
+	return withDigitalOceanClient(ctx, output, stdout, opts, logs, func(client *digitalocean.DigitalOcean) error {
+		// Cleanup resources
+		if err := client.NukeKubernetesClusters(ctx); err != nil {
+			return fmt.Errorf("unable to cleanup Kubernetes clusters: %w", err)
+		}
+
+		if err := client.NukeS3Storage(); err != nil {
+			return fmt.Errorf("unable to cleanup spaces storage: %w", err)
+		}
+
+		if err := client.NukeVolumes(ctx); err != nil {
+			return fmt.Errorf("unable to cleanup volumes: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func runDigitalOceanExpire(ctx context.Context, output, stdout io.Writer, opts doOptions, logs logOptions) error {
+	return withDigitalOceanClient(ctx, output, stdout, opts, logs, func(client *digitalocean.DigitalOcean) error {
+		return client.Expire(ctx) //nolint:wrapcheck // the error is already wrapped
+	})
+}
+
+// withDigitalOceanClient creates a DigitalOcean client, runs fn with it and
+// then prints a summary per resource type and writes the structured output,
+// if requested, to stdout.
+func withDigitalOceanClient(ctx context.Context, output, stdout io.Writer, opts doOptions, logs logOptions, fn func(*digitalocean.DigitalOcean) error) error {
+	format, recorder, err := newRecorder(opts.output, output)
+	if err != nil {
+		return err
+	}
+
+	opts.recorder = recorder
+
+	client, err := newDigitalOceanClient(ctx, output, opts, logs)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(fn(client), writeReport(output, stdout, format, recorder, logs.quiet))
+}
+
+// newDigitalOceanClient validates the credentials and creates a DigitalOcean
+// client with a logger writing to output, configured by the log options.
+func newDigitalOceanClient(ctx context.Context, output io.Writer, opts doOptions, logs logOptions) (*digitalocean.DigitalOcean, error) {
 	// Check token
 	if opts.token == "" {
-		return errors.New("required environment variable $DIGITALOCEAN_TOKEN not set")
+		return nil, errors.New("required environment variable $DIGITALOCEAN_TOKEN not set")
 	}
 
 	// Check spaces credentials
 	if opts.spacesAccessKey == "" {
-		return errors.New("required environment variable $DIGITALOCEAN_SPACES_ACCESS_KEY or $SPACES_KEY not set")
+		return nil, errors.New("required environment variable $DIGITALOCEAN_SPACES_ACCESS_KEY or $SPACES_KEY not set")
 	}
 	if opts.spacesSecretKey == "" {
-		return errors.New("required environment variable $DIGITALOCEAN_SPACES_SECRET_KEY or $SPACES_SECRET not set")
+		return nil, errors.New("required environment variable $DIGITALOCEAN_SPACES_SECRET_KEY or $SPACES_SECRET not set")
 	}
 	if opts.spacesRegion == "" {
-		return errors.New("required environment variable $DIGITALOCEAN_SPACES_REGION or $SPACES_REGION not set")
+		return nil, errors.New("required environment variable $DIGITALOCEAN_SPACES_REGION or $SPACES_REGION not set")
 	}
 
-	// Create a logger and make it quiet
-	var log *logger.Logger
-	if quiet {
-		log = logger.New(io.Discard)
-	} else {
-		log = logger.New(output)
+	rules, err := loadProtectFile(opts.protectFile, opts.profileRules)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := newNameMatcher(opts.names)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := newAgeFilter(opts.age)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := newLogger(output, logs)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create DigitalOcean client
@@ -73,23 +234,14 @@ func runDigitalOcean(ctx context.Context, output io.Writer, opts doOptions, quie
 		digitalocean.WithS3Storage(opts.spacesAccessKey, opts.spacesSecretKey, opts.spacesRegion),
 		digitalocean.WithNuke(opts.nuke),
 		digitalocean.WithLogger(log),
+		digitalocean.WithProtect(rules),
+		digitalocean.WithNameMatcher(names),
+		digitalocean.WithAgeFilter(filter),
+		digitalocean.WithRecorder(opts.recorder),
 	)
 	if err != nil {
-		return fmt.Errorf("unable to create new client: %w", err)
-	}
-
-	// Cleanup resources
-	if err := client.NukeKubernetesClusters(ctx); err != nil {
-		return fmt.Errorf("unable to cleanup Kubernetes clusters: %w", err)
-	}
-
-	if err := client.NukeS3Storage(); err != nil {
-		return fmt.Errorf("unable to cleanup spaces storage: %w", err)
-	}
-
-	if err := client.NukeVolumes(ctx); err != nil {
-		return fmt.Errorf("unable to cleanup volumes: %w", err)
+		return nil, fmt.Errorf("unable to create new client: %w", err)
 	}
 
-	return nil
+	return client, nil
 }
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// logOptions are the global flags configuring what is logged and how.
+type logOptions struct {
+	quiet  bool
+	level  string
+	format string
+}
+
+// addLogFlags registers the global flags configuring the logger.
+func addLogFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "suppress output from processing while keeping deletion messages to stdout")
+	cmd.PersistentFlags().String("log-level", "info", `the minimum level of the logged messages: "debug", "info", "warn" or "error"`)
+	cmd.PersistentFlags().String("log-format", string(logger.FormatText), `the format of the logged messages: "text" or "json"`)
+}
+
+// getLogOptions reads the global logger flags of a command.
+func getLogOptions(cmd *cobra.Command) logOptions {
+	return logOptions{
+		quiet:  cmd.Flags().Lookup("quiet").Value.String() == "true",
+		level:  cmd.Flags().Lookup("log-level").Value.String(),
+		format: cmd.Flags().Lookup("log-format").Value.String(),
+	}
+}
+
+// newLogger creates a logger writing to output with the given options, or
+// discarding everything if the quiet option is set.
+func newLogger(output io.Writer, opts logOptions) (*logger.Logger, error) {
+	level, err := logger.ParseLevel(opts.level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for --log-level: %w", err)
+	}
+
+	format, err := logger.ParseFormat(opts.format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for --log-format: %w", err)
+	}
+
+	if opts.quiet {
+		return logger.None, nil
+	}
+
+	return logger.NewWithOptions(output, logger.Options{Level: level, Format: format}), nil
+}
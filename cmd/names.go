@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/konstructio/dropkick/internal/matcher"
+	"github.com/spf13/cobra"
+)
+
+// nameOptions holds the flags used to select resources by name.
+type nameOptions struct {
+	matcher.Options
+	match string
+}
+
+// addNameFlags registers the flags used to select resources by name. Every
+// flag can be repeated to provide multiple values.
+func addNameFlags(cmd *cobra.Command, opts *nameOptions) {
+	cmd.Flags().StringArrayVar(&opts.Contains, "name-contains", nil, "only select resources with a name containing this string, ignoring case (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Prefixes, "name-prefix", nil, "only select resources with a name starting with this prefix, ignoring case (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.Globs, "name-glob", nil, `only select resources with a name matching this glob pattern, like "ci-*-k3s", ignoring case (repeatable)`)
+	cmd.Flags().StringArrayVar(&opts.Regexes, "name-regex", nil, `only select resources with a name matching this regular expression, like "^ci-\d+-" (repeatable)`)
+	cmd.Flags().StringArrayVar(&opts.NotContains, "name-not-contains", nil, "never select resources with a name containing this string, ignoring case (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.NotPrefixes, "name-not-prefix", nil, "never select resources with a name starting with this prefix, ignoring case (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.NotGlobs, "name-not-glob", nil, "never select resources with a name matching this glob pattern, ignoring case (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.NotRegexes, "name-not-regex", nil, "never select resources with a name matching this regular expression (repeatable)")
+	cmd.Flags().StringVar(&opts.match, "name-match", "any", `whether a name must match "any" or "all" of the --name-contains, --name-prefix, --name-glob and --name-regex values`)
+}
+
+// newNameMatcher creates the name matcher from the name flags.
+func newNameMatcher(opts nameOptions) (*matcher.Matcher, error) {
+	switch opts.match {
+	case "any", "":
+		opts.MatchAll = false
+	case "all":
+		opts.MatchAll = true
+	default:
+		return nil, fmt.Errorf(`invalid value %q for --name-match: expected "any" or "all"`, opts.match)
+	}
+
+	names, err := matcher.New(opts.Options)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create name matcher: %w", err)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/konstructio/dropkick/internal/outputwriter"
+	"github.com/konstructio/dropkick/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// addOutputFlag registers the flag used to choose the structured output
+// format.
+func addOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVarP(output, "output", "o", string(report.FormatText), `the structured output written to stdout: "text" (none), "json", "yaml", "csv" or "table", with a record for every resource found`)
+}
+
+// newRecorder validates the output format and returns a recorder collecting
+// a record for every resource found. When structured output is requested,
+// deletion messages are written to stderr instead, so stdout only holds the
+// structured output.
+func newRecorder(output string, stderr io.Writer) (report.Format, *report.Recorder, error) {
+	format, err := report.ParseFormat(output)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid value for --output: %w", err)
+	}
+
+	if format != report.FormatText {
+		outputwriter.SetStdout(stderr)
+	}
+
+	return format, report.NewRecorder(), nil
+}
+
+// writeReport prints the summary of the records collected by the recorder
+// to output, unless quiet is set, and writes the records and the summary to
+// stdout in the given format.
+func writeReport(output, stdout io.Writer, format report.Format, recorder *report.Recorder, quiet bool) error {
+	rep := recorder.Report()
+
+	if !quiet {
+		if err := report.WriteSummary(output, rep.Summary); err != nil {
+			return err //nolint:wrapcheck // the error is already wrapped
+		}
+	}
+
+	if err := report.Write(stdout, format, rep); err != nil {
+		return fmt.Errorf("unable to write output: %w", err)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/konstructio/dropkick/internal/protect"
 	"github.com/spf13/cobra"
 )
 
@@ -17,18 +18,40 @@ func Execute() {
 		Long:          ``,
 		SilenceUsage:  true, // prevents printing usage when an error occurs
 		SilenceErrors: true, // we want to print the error ourselves
+		// Flags not set by the operator are set from the selected profile
+		PersistentPreRunE: loadProfile,
 	}
 
 	// Add subcommands
 	rootCmd.AddCommand(getCivoCommand())
 	rootCmd.AddCommand(getDigitalOceanCommand())
 	rootCmd.AddCommand(getVersionCommand())
+	rootCmd.AddCommand(getConfigCommand())
 
-	// Configure a global flag for "--quiet"
-	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "suppress output from processing while keeping deletion messages to stdout")
+	// Configure the global flags for "--quiet", "--log-level" and "--log-format"
+	addLogFlags(rootCmd)
+
+	// Configure the global flags for "--config" and "--profile"
+	addConfigFlags(rootCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
 }
+
+// loadProtectFile loads the protect rules from the given file, merged with
+// the protect rules of the profile, or returns nil rules, which protect
+// nothing, if there are neither.
+func loadProtectFile(filename string, profileRules *protect.Rules) (*protect.Rules, error) {
+	if filename == "" {
+		return profileRules, nil
+	}
+
+	rules, err := protect.Load(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load protect rules: %w", err)
+	}
+
+	return protect.Merge(profileRules, rules), nil
+}
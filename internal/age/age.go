@@ -0,0 +1,114 @@
+package age
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MissingPolicy decides what happens to resources without a creation
+// timestamp when filtering by age.
+type MissingPolicy string
+
+const (
+	// MissingSkip skips resources without a creation timestamp.
+	MissingSkip MissingPolicy = "skip"
+
+	// MissingInclude selects resources without a creation timestamp, as if
+	// they matched the age filter.
+	MissingInclude MissingPolicy = "include"
+)
+
+// ParseMissingPolicy converts a string into a MissingPolicy. It returns an
+// error if the string is not a known policy.
+func ParseMissingPolicy(s string) (MissingPolicy, error) {
+	switch p := MissingPolicy(strings.ToLower(s)); p {
+	case MissingSkip, MissingInclude:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown missing timestamp policy %q: expected %q or %q", s, MissingSkip, MissingInclude)
+	}
+}
+
+// Filter selects resources based on how long ago they were created. A nil
+// Filter, or one without limits, selects every resource.
+type Filter struct {
+	olderThan time.Duration
+	newerThan time.Duration
+	missing   MissingPolicy
+	now       func() time.Time
+}
+
+// New creates a Filter selecting resources created more than olderThan ago
+// and less than newerThan ago. A zero duration disables that limit. It
+// returns an error if a duration is negative or if no resource could ever
+// match both limits.
+func New(olderThan, newerThan time.Duration, missing MissingPolicy) (*Filter, error) {
+	if olderThan < 0 || newerThan < 0 {
+		return nil, errors.New("age limits must not be negative")
+	}
+
+	if olderThan > 0 && newerThan > 0 && olderThan >= newerThan {
+		return nil, fmt.Errorf("no resource can be older than %s and newer than %s", olderThan, newerThan)
+	}
+
+	if missing == "" {
+		missing = MissingSkip
+	}
+
+	if _, err := ParseMissingPolicy(string(missing)); err != nil {
+		return nil, err
+	}
+
+	return &Filter{olderThan: olderThan, newerThan: newerThan, missing: missing, now: time.Now}, nil
+}
+
+// Match checks if a resource created at the given time is selected. A zero
+// time means the creation time is unknown, and is handled according to the
+// missing timestamp policy. If the resource isn't selected, it also returns
+// the reason why, which can be used in log messages.
+func (f *Filter) Match(createdAt time.Time) (bool, string) {
+	if f == nil || (f.olderThan == 0 && f.newerThan == 0) {
+		return true, ""
+	}
+
+	if createdAt.IsZero() {
+		if f.missing == MissingInclude {
+			return true, ""
+		}
+
+		return false, "its creation time is unknown"
+	}
+
+	age := f.now().Sub(createdAt)
+
+	if f.olderThan > 0 && age < f.olderThan {
+		return false, fmt.Sprintf("it was created %s ago, not more than %s ago", age.Round(time.Second), f.olderThan)
+	}
+
+	if f.newerThan > 0 && age > f.newerThan {
+		return false, fmt.Sprintf("it was created %s ago, not less than %s ago", age.Round(time.Second), f.newerThan)
+	}
+
+	return true, ""
+}
+
+// String returns a description of the filter, or an empty string if it
+// selects every resource.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+
+	var parts []string
+	if f.olderThan > 0 {
+		parts = append(parts, fmt.Sprintf("created more than %s ago", f.olderThan))
+	}
+
+	if f.newerThan > 0 {
+		parts = append(parts, fmt.Sprintf("created less than %s ago", f.newerThan))
+	}
+
+	return strings.Join(parts, " and ")
+}
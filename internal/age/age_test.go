@@ -0,0 +1,75 @@
+package age
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatch(t *testing.T) {
+	now := time.Date(2024, 8, 17, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		olderThan time.Duration
+		newerThan time.Duration
+		missing   MissingPolicy
+		createdAt time.Time
+		want      bool
+	}{
+		{name: "no limits", createdAt: now, want: true},
+		{name: "older than", olderThan: 6 * time.Hour, createdAt: now.Add(-7 * time.Hour), want: true},
+		{name: "not older than", olderThan: 6 * time.Hour, createdAt: now.Add(-5 * time.Minute), want: false},
+		{name: "newer than", newerThan: time.Hour, createdAt: now.Add(-5 * time.Minute), want: true},
+		{name: "not newer than", newerThan: time.Hour, createdAt: now.Add(-2 * time.Hour), want: false},
+		{name: "between", olderThan: time.Hour, newerThan: 24 * time.Hour, createdAt: now.Add(-2 * time.Hour), want: true},
+		{name: "missing is skipped by default", olderThan: time.Hour, want: false},
+		{name: "missing is included", olderThan: time.Hour, missing: MissingInclude, want: true},
+		{name: "missing without limits", want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := New(tc.olderThan, tc.newerThan, tc.missing)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			f.now = func() time.Time { return now }
+
+			got, reason := f.Match(tc.createdAt)
+			if got != tc.want {
+				t.Fatalf("expecting match to be %v, got %v (reason: %q)", tc.want, got, reason)
+			}
+
+			if !got && reason == "" {
+				t.Fatalf("expecting a reason when the resource isn't selected")
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(-time.Hour, 0, MissingSkip); err == nil {
+		t.Fatalf("expecting an error for a negative duration")
+	}
+
+	if _, err := New(2*time.Hour, time.Hour, MissingSkip); err == nil {
+		t.Fatalf("expecting an error when no resource can match")
+	}
+
+	if _, err := New(time.Hour, 0, "maybe"); err == nil {
+		t.Fatalf("expecting an error for an unknown policy")
+	}
+}
+
+func TestNilFilter(t *testing.T) {
+	var f *Filter
+
+	if ok, _ := f.Match(time.Time{}); !ok {
+		t.Fatalf("expecting a nil filter to select everything")
+	}
+
+	if f.String() != "" {
+		t.Fatalf("expecting a nil filter to have no description")
+	}
+}
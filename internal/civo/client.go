@@ -4,16 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"net"
 	"net/http"
-	"os"
+	"time"
 
+	"github.com/konstructio/dropkick/internal/age"
 	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/json"
 	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/matcher"
+	"github.com/konstructio/dropkick/internal/protect"
+	"github.com/konstructio/dropkick/internal/report"
+	"github.com/konstructio/dropkick/internal/tags"
 )
 
 const civoAPIURL = "https://api.civo.com"
 
+// maxRetryDelay is the longest time to wait between two retries of a failed
+// request to the Civo API, unless the API asks for longer via Retry-After.
+const maxRetryDelay = 30 * time.Second
+
 // Client is the interface that wraps the basic Civo API client methods.
 type Client interface {
 	GetInstances(ctx context.Context) ([]sdk.Instance, error)
@@ -25,19 +35,65 @@ type Client interface {
 	GetObjectStoreCredentials(ctx context.Context) ([]sdk.ObjectStoreCredential, error)
 	GetLoadBalancers(ctx context.Context) ([]sdk.LoadBalancer, error)
 	GetSSHKeys(ctx context.Context) ([]sdk.SSHKey, error)
+	GetReservedIPs(ctx context.Context) ([]sdk.ReservedIP, error)
+	GetDatabases(ctx context.Context) ([]sdk.Database, error)
+	GetDNSDomains(ctx context.Context) ([]sdk.DNSDomain, error)
+	GetDNSRecords(ctx context.Context, domainID string) ([]sdk.DNSRecord, error)
+	GetSnapshots(ctx context.Context) ([]sdk.Snapshot, error)
+	GetRegions(ctx context.Context) ([]sdk.Region, error)
+	InRegion(region string) Client
+	Get(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error)
 	Delete(ctx context.Context, resource sdk.APIResource) error
 	Each(ctx context.Context, v sdk.APIResource, iterator func(sdk.APIResource) error) error
 }
 
+// sdkClient adapts the Civo SDK client to the Client interface.
+type sdkClient struct {
+	*sdk.Client
+}
+
+// InRegion returns a client for the same account targeting another region.
+//
+//nolint:ireturn // the client is returned as the interface it's used through
+func (s sdkClient) InRegion(region string) Client {
+	return sdkClient{s.Client.InRegion(region)}
+}
+
 // Civo is a client for the Civo API.
 type Civo struct {
-	client     Client       // The underlying Civo API client.
-	nuke       bool         // Whether to nuke resources.
-	region     string       // The region for API requests.
-	nameFilter string       // If set, only resources with a name containing this string will be deleted.
-	token      string       // The API token.
-	logger     customLogger // The logger instance.
-	apiURL     string       // The URL for the Civo API.
+	client       Client            // The underlying Civo API client.
+	nuke         bool              // Whether to nuke resources.
+	region       string            // The region for API requests.
+	nameFilter   string            // If set, only resources with a name containing this string will be deleted.
+	names        *matcher.Matcher  // If set, only resources with a name selected by this matcher will be deleted.
+	age          *age.Filter       // If set, only resources with a creation time selected by this filter will be deleted.
+	tags         *tags.Filter      // If set, only resources with tags selected by this filter will be deleted.
+	token        string            // The API token.
+	logger       customLogger      // The logger instance.
+	apiURL       string            // The URL for the Civo API.
+	plan         *Plan             // If set, resources are recorded into this plan instead of being deleted.
+	expiring     bool              // If set, only resources whose expiry tags say they have expired are deleted.
+	keepGoing    bool              // If set, failures are recorded and the run continues with the remaining resources.
+	failures     *failures         // The failures recorded during the current run.
+	kept         *keeper           // The resources kept because of protect rules during the current run.
+	report       *report.Recorder  // If set, a record of what was done with every resource found is added to it.
+	concurrency  int               // The maximum number of resources deleted at the same time.
+	waitTimeout  time.Duration     // How long to wait for a deleted resource to be gone. Zero disables waiting.
+	waitInterval time.Duration     // How long to wait before the first check for a deleted resource.
+	maxRetries   int               // How many times failed idempotent API requests are retried.
+	retryDelay   time.Duration     // The delay before the first retry, doubled on every retry.
+	maxRPS       float64           // The maximum number of requests per second sent to the Civo API. Zero means no limit.
+	protect      *protect.Rules    // Resources matching these rules are never deleted.
+	types        []sdk.APIResource // If set, only resources of these types are processed.
+	resolver     Resolver          // Resolves the targets of DNS records when looking for orphaned records.
+	keepExtDNS   bool              // If set, DNS records pointing at addresses no instance or load balancer uses are kept.
+	skipGlobal   bool              // If set, resources belonging to the whole account rather than a region are left alone.
+}
+
+// Resolver looks up the IP addresses of a host. It's satisfied by
+// *net.Resolver.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
 }
 
 // Option is a function that configures a Civo.
@@ -83,28 +139,195 @@ func WithNameFilter(nameFilter string) Option {
 	}
 }
 
+// WithNameMatcher sets the name matcher for a Civo. It's applied in addition
+// to the name filter.
+func WithNameMatcher(names *matcher.Matcher) Option {
+	return func(c *Civo) error {
+		c.names = names
+		return nil
+	}
+}
+
+// WithAgeFilter sets the age filter for a Civo.
+func WithAgeFilter(filter *age.Filter) Option {
+	return func(c *Civo) error {
+		c.age = filter
+		return nil
+	}
+}
+
+// WithTagFilter sets the tag filter for a Civo. When the filter requires
+// tags, resource types that don't support tags are never deleted.
+func WithTagFilter(filter *tags.Filter) Option {
+	return func(c *Civo) error {
+		c.tags = filter
+		return nil
+	}
+}
+
+// WithKeepGoing sets whether a Civo continues with the remaining resources
+// when a resource fails to be deleted. Every failure is then returned at
+// the end of the run, and resources depending on a failed one are skipped.
+func WithKeepGoing(keepGoing bool) Option {
+	return func(c *Civo) error {
+		c.keepGoing = keepGoing
+		return nil
+	}
+}
+
+// WithRecorder sets the recorder a Civo adds a record to for every resource
+// it finds, describing what was done with it.
+func WithRecorder(recorder *report.Recorder) Option {
+	return func(c *Civo) error {
+		c.report = recorder
+		return nil
+	}
+}
+
+// WithConcurrency sets the maximum number of resources a Civo deletes at
+// the same time. It must be at least 1.
+func WithConcurrency(concurrency int) Option {
+	return func(c *Civo) error {
+		if concurrency < 1 {
+			return fmt.Errorf("concurrency must be at least 1, got %d", concurrency)
+		}
+
+		c.concurrency = concurrency
+		return nil
+	}
+}
+
+// WithWaitForDeletion makes a Civo wait, after deleting a resource other
+// resource types depend on, until the resource is gone from the Civo API.
+// The resource is checked after interval, with the interval doubling after
+// every check, until the timeout is reached. A zero timeout disables waiting.
+func WithWaitForDeletion(timeout, interval time.Duration) Option {
+	return func(c *Civo) error {
+		if timeout > 0 && interval <= 0 {
+			return fmt.Errorf("wait interval must be positive, got %s", interval)
+		}
+
+		c.waitTimeout = timeout
+		c.waitInterval = interval
+		return nil
+	}
+}
+
+// WithRetries sets how many times failed idempotent requests to the Civo API
+// are retried, and the delay before the first retry. The delay doubles on
+// every retry, up to maxRetryDelay.
+func WithRetries(maxRetries int, delay time.Duration) Option {
+	return func(c *Civo) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("max retries must not be negative, got %d", maxRetries)
+		}
+
+		c.maxRetries = maxRetries
+		c.retryDelay = delay
+		return nil
+	}
+}
+
+// WithMaxRPS limits the number of requests per second a Civo sends to the
+// Civo API. A value of zero disables the limit.
+func WithMaxRPS(rps float64) Option {
+	return func(c *Civo) error {
+		if rps < 0 {
+			return fmt.Errorf("max requests per second must not be negative, got %v", rps)
+		}
+
+		c.maxRPS = rps
+		return nil
+	}
+}
+
+// WithProtect sets the rules of resources a Civo must never delete.
+func WithProtect(rules *protect.Rules) Option {
+	return func(c *Civo) error {
+		c.protect = rules
+		return nil
+	}
+}
+
+// WithResolver sets the resolver a Civo uses to find the IP addresses the
+// CNAME records point to when looking for orphaned DNS records. It defaults
+// to net.DefaultResolver.
+func WithResolver(resolver Resolver) Option {
+	return func(c *Civo) error {
+		c.resolver = resolver
+		return nil
+	}
+}
+
+// WithKeepExternalDNSRecords sets whether DNS records pointing at IP
+// addresses no instance or load balancer of the account uses, in any region,
+// are kept instead of being orphaned, for records pointing outside Civo.
+func WithKeepExternalDNSRecords(enabled bool) Option {
+	return func(c *Civo) error {
+		c.keepExtDNS = enabled
+		return nil
+	}
+}
+
+// WithGlobalResources sets whether a Civo processes the resources belonging
+// to the whole account rather than to its region, like DNS domains. They're
+// processed by default, and a run covering several regions should only
+// process them with one of them.
+func WithGlobalResources(enabled bool) Option {
+	return func(c *Civo) error {
+		c.skipGlobal = !enabled
+		return nil
+	}
+}
+
+// WithResourceTypes sets which resource types a Civo processes: only the
+// types in the only list, or every type if it's empty, minus the types in
+// the skip list. It returns an error if a type is unknown.
+func WithResourceTypes(only, skip []string) Option {
+	return func(c *Civo) error {
+		if len(only) == 0 && len(skip) == 0 {
+			c.types = nil
+			return nil
+		}
+
+		types, err := selectTypes(only, skip)
+		if err != nil {
+			return err
+		}
+
+		c.types = types
+		return nil
+	}
+}
+
 // customLogger is a custom logger interface.
 type customLogger interface {
+	Debugf(format string, v ...interface{})
 	Errorf(format string, v ...interface{})
 	Infof(format string, v ...interface{})
 	Warnf(format string, v ...interface{})
+	With(args ...any) *logger.Logger
 }
 
 // _ is a compile-time check to ensure that Civo implements
 // the customLogger interface.
 var _ customLogger = &logger.Logger{}
 
-// debuggableHTTPClient is an HTTP client that logs requests if
-// the HTTP_DEBUG environment variable is set.
-var debuggableHTTPClient = &http.Client{
-	Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
-		if os.Getenv("HTTP_DEBUG") == "" {
-			return http.DefaultTransport.RoundTrip(req)
-		}
+// resourceLogger returns a logger adding the type and ID of the resource as
+// attributes to every entry.
+func (c *Civo) resourceLogger(resource sdk.APIResource) customLogger {
+	return c.logger.With("type", resource.GetResourceType(), "id", resource.GetID())
+}
 
-		log.Printf("Request: %s %s", req.Method, req.URL.String())
-		return http.DefaultTransport.RoundTrip(req)
-	}),
+// debuggableHTTPClient returns an HTTP client that logs every request at the
+// debug level.
+func (c *Civo) debuggableHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			c.logger.Debugf("sending request %s %s", req.Method, req.URL.String())
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
 }
 
 // roundTripperFunc is a function that implements the http.RoundTripper interface.
@@ -118,6 +341,59 @@ func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 // New creates a new Civo with the given options.
 // It returns an error if the token or region is not set, or if it fails to create the underlying Civo API client.
 func New(opts ...Option) (*Civo, error) {
+	c, err := newCivo(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.region == "" {
+		return nil, errors.New("required region not set")
+	}
+
+	c.logger = c.logger.With("provider", "civo", "region", c.region)
+
+	client, err := c.newSDKClient()
+	if err != nil {
+		return nil, err
+	}
+
+	c.client = sdkClient{client}
+
+	return c, nil
+}
+
+// ListRegions returns the codes of every region available to the Civo
+// account, using the same options accepted by New. The region option, if
+// provided, is ignored.
+func ListRegions(ctx context.Context, opts ...Option) ([]string, error) {
+	c, err := newCivo(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.region = ""
+
+	client, err := c.newSDKClient()
+	if err != nil {
+		return nil, err
+	}
+
+	regions, err := client.GetRegions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Civo regions: %w", err)
+	}
+
+	codes := make([]string, 0, len(regions))
+	for _, region := range regions {
+		codes = append(codes, region.Code)
+	}
+
+	return codes, nil
+}
+
+// newCivo applies the options to a new Civo and sets the defaults. It
+// returns an error if the token is not set.
+func newCivo(opts ...Option) (*Civo, error) {
 	c := &Civo{}
 
 	for _, opt := range opts {
@@ -130,10 +406,6 @@ func New(opts ...Option) (*Civo, error) {
 		return nil, errors.New("required token not found")
 	}
 
-	if c.region == "" {
-		return nil, errors.New("required region not set")
-	}
-
 	if c.apiURL == "" {
 		c.apiURL = civoAPIURL
 	}
@@ -142,15 +414,32 @@ func New(opts ...Option) (*Civo, error) {
 		c.logger = logger.None
 	}
 
+	if c.concurrency == 0 {
+		c.concurrency = 1
+	}
+
+	if c.resolver == nil {
+		c.resolver = net.DefaultResolver
+	}
+
+	return c, nil
+}
+
+// newSDKClient creates the underlying Civo API client for the region
+// configured in the Civo.
+func (c *Civo) newSDKClient() (*sdk.Client, error) {
 	client, err := sdk.New(
 		sdk.WithRegion(c.region),
-		sdk.WithJSONClient(debuggableHTTPClient, c.apiURL, c.token),
+		sdk.WithJSONClient(
+			c.debuggableHTTPClient(), c.apiURL, c.token,
+			json.WithRetries(c.maxRetries, c.retryDelay, maxRetryDelay),
+			json.WithRateLimit(c.maxRPS),
+			json.WithLogger(c.logger),
+		),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create Civo client: %w", err)
 	}
 
-	c.client = client
-
-	return c, nil
+	return client, nil
 }
@@ -23,6 +23,14 @@ type mockClient struct {
 	fnGetObjectStoreCredentials func(ctx context.Context) ([]sdk.ObjectStoreCredential, error)
 	fnGetLoadBalancers          func(ctx context.Context) ([]sdk.LoadBalancer, error)
 	fnGetSSHKeys                func(ctx context.Context) ([]sdk.SSHKey, error)
+	fnGetReservedIPs            func(ctx context.Context) ([]sdk.ReservedIP, error)
+	fnGetDatabases              func(ctx context.Context) ([]sdk.Database, error)
+	fnGetDNSDomains             func(ctx context.Context) ([]sdk.DNSDomain, error)
+	fnGetDNSRecords             func(ctx context.Context, domainID string) ([]sdk.DNSRecord, error)
+	fnGetSnapshots              func(ctx context.Context) ([]sdk.Snapshot, error)
+	fnGetRegions                func(ctx context.Context) ([]sdk.Region, error)
+	fnInRegion                  func(region string) Client
+	fnGet                       func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error)
 	fnDelete                    func(ctx context.Context, resource sdk.APIResource) error
 	fnEach                      func(ctx context.Context, v sdk.APIResource, iterator func(sdk.APIResource) error) error
 }
@@ -66,6 +74,38 @@ func (m *mockClient) GetSSHKeys(ctx context.Context) ([]sdk.SSHKey, error) {
 	return m.fnGetSSHKeys(ctx)
 }
 
+func (m *mockClient) GetReservedIPs(ctx context.Context) ([]sdk.ReservedIP, error) {
+	return m.fnGetReservedIPs(ctx)
+}
+
+func (m *mockClient) GetDatabases(ctx context.Context) ([]sdk.Database, error) {
+	return m.fnGetDatabases(ctx)
+}
+
+func (m *mockClient) GetDNSDomains(ctx context.Context) ([]sdk.DNSDomain, error) {
+	return m.fnGetDNSDomains(ctx)
+}
+
+func (m *mockClient) GetDNSRecords(ctx context.Context, domainID string) ([]sdk.DNSRecord, error) {
+	return m.fnGetDNSRecords(ctx, domainID)
+}
+
+func (m *mockClient) GetSnapshots(ctx context.Context) ([]sdk.Snapshot, error) {
+	return m.fnGetSnapshots(ctx)
+}
+
+func (m *mockClient) GetRegions(ctx context.Context) ([]sdk.Region, error) {
+	return m.fnGetRegions(ctx)
+}
+
+func (m *mockClient) InRegion(region string) Client {
+	return m.fnInRegion(region)
+}
+
+func (m *mockClient) Get(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+	return m.fnGet(ctx, resource)
+}
+
 func (m *mockClient) Delete(ctx context.Context, resource sdk.APIResource) error {
 	return m.fnDelete(ctx, resource)
 }
@@ -0,0 +1,41 @@
+package civo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/expiry"
+	"github.com/konstructio/dropkick/internal/report"
+)
+
+// Expire walks the Civo account in the same way NukeEverything does, but
+// only deletes the resources whose dropkick-ttl or dropkick-expires tag says
+// they have expired. Resources without one of those tags, including every
+// resource whose type doesn't support tags, are left alone. The nuke setting
+// is still required to actually delete the expired resources.
+func (c *Civo) Expire(ctx context.Context) error {
+	c.expiring = true
+	defer func() { c.expiring = false }()
+
+	if err := c.NukeEverything(ctx); err != nil {
+		return fmt.Errorf("unable to expire resources: %w", err)
+	}
+
+	return nil
+}
+
+// isExpired checks if a resource has expired according to its tags, and
+// logs the decision.
+func (c *Civo) isExpired(resource sdk.APIResource, resourceTags []string) bool {
+	expired, reason := expiry.Check(resourceTags, resource.GetCreatedAt(), time.Now())
+	if !expired {
+		c.resourceLogger(resource).Infof("skipping %s %q: %s", resource.GetResourceType(), resource.GetName(), reason)
+		c.record(resource, false, report.ActionSkipped, reason)
+		return false
+	}
+
+	c.resourceLogger(resource).Infof("%s %q is expired: %s", resource.GetResourceType(), resource.GetName(), reason)
+	return true
+}
@@ -0,0 +1,62 @@
+package civo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+	"github.com/konstructio/dropkick/internal/logger"
+)
+
+func TestExpire(t *testing.T) {
+	created := sdk.Timestamp{Time: time.Now().Add(-5 * time.Hour)}
+
+	var (
+		mu      sync.Mutex
+		listed  []string
+		deleted []string
+	)
+
+	mock := &mockClient{
+		fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+			mu.Lock()
+			listed = append(listed, resource.GetResourceType())
+			mu.Unlock()
+
+			switch resource.(type) {
+			case sdk.Instance:
+				return runEach([]sdk.Instance{
+					{ID: "1", Name: "expired-ttl", Tags: []string{"dropkick-ttl=4h"}, CreatedAt: created},
+					{ID: "2", Name: "alive-ttl", Tags: []string{"dropkick-ttl=6h"}, CreatedAt: created},
+					{ID: "3", Name: "untagged", CreatedAt: created},
+				}, fn)
+			case sdk.KubernetesCluster:
+				return runEach([]sdk.KubernetesCluster{
+					{ID: "4", Name: "expired-date", Tags: []string{"dropkick-expires:2020-01-01T00:00Z"}},
+				}, fn)
+			default:
+				return nil
+			}
+		},
+		fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+			mu.Lock()
+			deleted = append(deleted, resource.GetName())
+			mu.Unlock()
+			return nil
+		},
+	}
+
+	c := &Civo{client: mock, logger: logger.None, nuke: true}
+
+	err := c.Expire(context.Background())
+	testutils.AssertNoErrorf(t, err, "expected no error when calling Expire, got %v", err)
+
+	testutils.AssertEqualf(t, 2, len(listed), "expected only taggable types to be listed, got %v", listed)
+	testutils.AssertEqualf(t, 2, len(deleted), "expected two resources to be deleted, got %v", deleted)
+	testutils.AssertEqualf(t, "expired-date", deleted[0], "expected the expired cluster to be deleted first, got %v", deleted)
+	testutils.AssertEqualf(t, "expired-ttl", deleted[1], "expected the expired instance to be deleted, got %v", deleted)
+	testutils.AssertEqualf(t, false, c.expiring, "expected expiring to be reset after Expire")
+}
@@ -0,0 +1,145 @@
+package civo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+)
+
+// ResourceError is a failure to process a single resource, or every
+// resource of a type when ID is empty, recorded when keep going is enabled.
+type ResourceError struct {
+	ResourceType string
+	ID           string
+	Name         string
+	Err          error
+
+	resource sdk.APIResource // the resource that failed, used to find the resources it blocks
+	cause    *ResourceError  // the failure blocking this resource, if it was skipped instead of failing
+}
+
+// Error returns the error message, by implementing the error interface.
+func (e *ResourceError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
+
+// describe returns a description of a failure, to be used as the reason
+// resources blocked by it are skipped.
+func (e *ResourceError) describe() string {
+	if e.cause != nil {
+		return fmt.Sprintf("%s %q (ID: %q), which is blocked by %s", e.ResourceType, e.Name, e.ID, e.cause.describe())
+	}
+
+	if e.ID == "" {
+		return fmt.Sprintf("resources of type %q, which failed to be listed", e.ResourceType)
+	}
+
+	return fmt.Sprintf("%s %q (ID: %q), which failed to be deleted", e.ResourceType, e.Name, e.ID)
+}
+
+// failures records every resource that failed to be processed during a
+// run, so the run can continue with the remaining resources and report
+// every failure at the end. Resources skipped because of a failure are
+// recorded too, so the resources depending on them are skipped as well,
+// but they aren't failures themselves. A nil failures records nothing.
+type failures struct {
+	mu      sync.Mutex
+	errs    []*ResourceError
+	blocked []*ResourceError
+}
+
+// add records a failure for the given resource.
+func (f *failures) add(resource sdk.APIResource, err error) {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.errs = append(f.errs, &ResourceError{
+		ResourceType: resource.GetResourceType(),
+		ID:           resource.GetID(),
+		Name:         resource.GetName(),
+		Err:          err,
+		resource:     resource,
+	})
+}
+
+// hold records that the given resource was skipped because of a failure,
+// so the resources depending on it are blocked by the same failure.
+func (f *failures) hold(resource sdk.APIResource, cause *ResourceError) {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.blocked = append(f.blocked, &ResourceError{
+		ResourceType: resource.GetResourceType(),
+		ID:           resource.GetID(),
+		Name:         resource.GetName(),
+		Err:          cause,
+		resource:     resource,
+		cause:        cause,
+	})
+}
+
+// blocker returns the recorded failure, if any, that prevents the given
+// resource from being deleted: a failed resource the given one depends on,
+// a resource the given one depends on that was itself blocked, or a whole
+// resource type that failed to be listed, since any of its resources could
+// be the one the given resource depends on.
+func (f *failures) blocker(resource sdk.APIResource) (*ResourceError, bool) {
+	if f == nil {
+		return nil, false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, failed := range slices.Concat(f.errs, f.blocked) {
+		for _, d := range dependencies {
+			if d.blocker.GetResourceType() != failed.ResourceType || d.blocked.GetResourceType() != resource.GetResourceType() {
+				continue
+			}
+
+			if failed.ID == "" || d.linked(failed.resource, resource) {
+				return failed, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// err returns every recorded failure joined into a single error, or nil if
+// nothing failed.
+func (f *failures) err() error {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.errs) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(f.errs))
+	for _, e := range f.errs {
+		errs = append(errs, e)
+	}
+
+	return fmt.Errorf("%d resources failed: %w", len(errs), errors.Join(errs...))
+}
@@ -0,0 +1,266 @@
+package civo
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/report"
+)
+
+func TestKeepGoing(t *testing.T) {
+	t.Run("failures are aggregated and dependents are skipped", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			deleted []string
+		)
+
+		mock := &mockClient{
+			fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+				switch resource.(type) {
+				case sdk.Instance:
+					return runEach([]sdk.Instance{
+						{ID: "i1", Name: "stuck-instance", NetworkID: "n1"},
+						{ID: "i2", Name: "instance", NetworkID: "n2"},
+					}, fn)
+				case sdk.Network:
+					return runEach([]sdk.Network{
+						{ID: "n1", Label: "blocked-network"},
+						{ID: "n2", Label: "network"},
+					}, fn)
+				default:
+					return nil
+				}
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				if resource.GetID() == "i1" {
+					return errors.New("instance is stuck")
+				}
+
+				mu.Lock()
+				deleted = append(deleted, resource.GetID())
+				mu.Unlock()
+				return nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, nuke: true, keepGoing: true}
+
+		err := c.NukeEverything(context.Background())
+		testutils.AssertErrorf(t, err, "expected the failure to be returned")
+
+		var resErr *ResourceError
+		if !errors.As(err, &resErr) {
+			t.Fatalf("expected a ResourceError, got %v", err)
+		}
+
+		testutils.AssertEqualf(t, "instance", resErr.ResourceType, "expected the failed resource type, got %q", resErr.ResourceType)
+		testutils.AssertEqualf(t, "i1", resErr.ID, "expected the failed resource ID, got %q", resErr.ID)
+
+		slices.Sort(deleted)
+		testutils.AssertEqualf(t, 2, len(deleted), "expected two resources to be deleted, got %v", deleted)
+		testutils.AssertEqualf(t, "i2", deleted[0], "expected the other instance to be deleted, got %v", deleted)
+		testutils.AssertEqualf(t, "n2", deleted[1], "expected the unblocked network to be deleted, got %v", deleted)
+	})
+
+	t.Run("failing to list a type blocks every dependent resource", func(t *testing.T) {
+		var deleted []string
+
+		mock := &mockClient{
+			fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+				switch resource.(type) {
+				case sdk.Firewall:
+					return errors.New("firewalls are unavailable")
+				case sdk.Network:
+					return runEach([]sdk.Network{{ID: "n1", Label: "network"}}, fn)
+				case sdk.SSHKey:
+					return runEach([]sdk.SSHKey{{ID: "k1", Name: "key"}}, fn)
+				default:
+					return nil
+				}
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				deleted = append(deleted, resource.GetID())
+				return nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, nuke: true, keepGoing: true}
+
+		err := c.NukeEverything(context.Background())
+		testutils.AssertErrorf(t, err, "expected the failure to be returned")
+
+		testutils.AssertEqualf(t, 1, len(deleted), "expected only the SSH key to be deleted, got %v", deleted)
+		testutils.AssertEqualf(t, "k1", deleted[0], "expected the SSH key to be deleted, got %v", deleted)
+	})
+
+	t.Run("resources blocked by a skipped resource are skipped too", func(t *testing.T) {
+		var deleted []string
+
+		mock := &mockClient{
+			fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+				switch resource.(type) {
+				case sdk.LoadBalancer:
+					return runEach([]sdk.LoadBalancer{{ID: "lb1", Name: "stuck-lb", ClusterID: "k1"}}, fn)
+				case sdk.KubernetesCluster:
+					return runEach([]sdk.KubernetesCluster{{ID: "k1", Name: "cluster", FirewallID: "f1"}}, fn)
+				case sdk.Firewall:
+					return runEach([]sdk.Firewall{
+						{ID: "f1", Name: "cluster-firewall"},
+						{ID: "f2", Name: "firewall"},
+					}, fn)
+				default:
+					return nil
+				}
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				if resource.GetID() == "lb1" {
+					return errors.New("load balancer is stuck")
+				}
+
+				deleted = append(deleted, resource.GetID())
+				return nil
+			},
+		}
+
+		recorder := report.NewRecorder()
+		c := &Civo{client: mock, logger: logger.None, nuke: true, keepGoing: true, report: recorder}
+
+		err := c.NukeEverything(context.Background())
+		testutils.AssertErrorf(t, err, "expected the failure to be returned")
+
+		if !strings.Contains(err.Error(), "1 resources failed") {
+			t.Fatalf("expected only the load balancer to be reported as failed, got %v", err)
+		}
+
+		testutils.AssertEqualf(t, 1, len(deleted), "expected only the unrelated firewall to be deleted, got %v", deleted)
+		testutils.AssertEqualf(t, "f2", deleted[0], "expected the unrelated firewall to be deleted, got %v", deleted)
+
+		reasons := make(map[string]string)
+		for _, r := range recorder.Report().Resources {
+			if r.Action == report.ActionSkipped {
+				reasons[r.ID] = r.Reason
+			}
+		}
+
+		if !strings.Contains(reasons["k1"], `"stuck-lb"`) {
+			t.Fatalf("expected the cluster to be blocked by the load balancer, got %q", reasons["k1"])
+		}
+
+		if !strings.Contains(reasons["f1"], `"cluster"`) || !strings.Contains(reasons["f1"], `"stuck-lb"`) {
+			t.Fatalf("expected the firewall to be blocked by the cluster and the load balancer, got %q", reasons["f1"])
+		}
+	})
+
+	t.Run("failures are returned by orphaned resources runs", func(t *testing.T) {
+		mock := &mockClient{
+			fnGetInstances:              func(ctx context.Context) ([]sdk.Instance, error) { return nil, nil },
+			fnGetVolumes:                func(ctx context.Context) ([]sdk.Volume, error) { return nil, nil },
+			fnGetLoadBalancers:          func(ctx context.Context) ([]sdk.LoadBalancer, error) { return nil, nil },
+			fnGetObjectStores:           func(ctx context.Context) ([]sdk.ObjectStore, error) { return nil, nil },
+			fnGetObjectStoreCredentials: func(ctx context.Context) ([]sdk.ObjectStoreCredential, error) { return nil, nil },
+			fnGetFirewalls:              func(ctx context.Context) ([]sdk.Firewall, error) { return nil, nil },
+			fnGetReservedIPs:            func(ctx context.Context) ([]sdk.ReservedIP, error) { return nil, nil },
+			fnGetDNSDomains:             func(ctx context.Context) ([]sdk.DNSDomain, error) { return nil, nil },
+			fnGetKubernetesClusters:     func(ctx context.Context) ([]sdk.KubernetesCluster, error) { return nil, nil },
+			fnGetSnapshots:              func(ctx context.Context) ([]sdk.Snapshot, error) { return nil, nil },
+			fnGetDatabases:              func(ctx context.Context) ([]sdk.Database, error) { return nil, nil },
+			fnGetSSHKeys: func(ctx context.Context) ([]sdk.SSHKey, error) {
+				return []sdk.SSHKey{{ID: "k1", Name: "key-1"}, {ID: "k2", Name: "key-2"}}, nil
+			},
+			fnGetNetworks: func(ctx context.Context) ([]sdk.Network, error) {
+				return []sdk.Network{{ID: "n1", Label: "network"}}, nil
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				if _, ok := resource.(sdk.SSHKey); ok {
+					return errors.New("unable to delete key")
+				}
+
+				return nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, nuke: true, keepGoing: true}
+
+		err := c.NukeOrphanedResources(context.Background())
+		testutils.AssertErrorf(t, err, "expected the failures to be returned")
+
+		if !strings.Contains(err.Error(), "2 resources failed") {
+			t.Fatalf("expected both SSH keys to be reported, got %v", err)
+		}
+	})
+}
+
+func TestKeepGoingOrphans(t *testing.T) {
+	var deleted []string
+
+	mock := &mockClient{
+		fnGetInstances: func(ctx context.Context) ([]sdk.Instance, error) {
+			return nil, errors.New("instances are unavailable")
+		},
+		fnGetVolumes:            func(ctx context.Context) ([]sdk.Volume, error) { return nil, nil },
+		fnGetKubernetesClusters: func(ctx context.Context) ([]sdk.KubernetesCluster, error) { return nil, nil },
+		fnGetLoadBalancers:      func(ctx context.Context) ([]sdk.LoadBalancer, error) { return nil, nil },
+		fnGetDatabases:          func(ctx context.Context) ([]sdk.Database, error) { return nil, nil },
+		fnGetReservedIPs: func(ctx context.Context) ([]sdk.ReservedIP, error) {
+			return nil, errors.New("reserved IPs are unavailable")
+		},
+		fnGetObjectStores: func(ctx context.Context) ([]sdk.ObjectStore, error) { return nil, nil },
+		fnGetObjectStoreCredentials: func(ctx context.Context) ([]sdk.ObjectStoreCredential, error) {
+			return []sdk.ObjectStoreCredential{{ID: "c1", Name: "credential"}}, nil
+		},
+		fnGetSSHKeys: func(ctx context.Context) ([]sdk.SSHKey, error) {
+			t.Fatalf("expected SSH keys to be skipped without the instances using them")
+			return nil, nil
+		},
+		fnGetFirewalls:  func(ctx context.Context) ([]sdk.Firewall, error) { return nil, nil },
+		fnGetDNSDomains: func(ctx context.Context) ([]sdk.DNSDomain, error) { return nil, nil },
+		fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+			deleted = append(deleted, resource.GetID())
+			return nil
+		},
+	}
+
+	t.Run("list failures are recorded and the run continues", func(t *testing.T) {
+		deleted = nil
+		c := &Civo{client: mock, logger: logger.None, nuke: true, keepGoing: true}
+
+		err := c.NukeOrphanedResources(context.Background())
+		testutils.AssertErrorf(t, err, "expected the failures to be returned")
+
+		if !strings.Contains(err.Error(), "2 resources failed") || !strings.Contains(err.Error(), "instances are unavailable") || !strings.Contains(err.Error(), "reserved IPs are unavailable") {
+			t.Fatalf("expected both list failures to be reported, got %v", err)
+		}
+
+		testutils.AssertEqualf(t, 1, len(deleted), "expected the orphaned credential to be deleted, got %v", deleted)
+		testutils.AssertEqualf(t, "c1", deleted[0], "expected the orphaned credential to be deleted, got %v", deleted)
+	})
+
+	t.Run("list failures stop the run without keep going", func(t *testing.T) {
+		deleted = nil
+		c := &Civo{client: mock, logger: logger.None, nuke: true}
+
+		err := c.NukeOrphanedResources(context.Background())
+		testutils.AssertErrorf(t, err, "expected the failure to be returned")
+		testutils.AssertEqualf(t, 0, len(deleted), "expected nothing to be deleted, got %v", deleted)
+	})
+}
+
+func TestNilFailures(t *testing.T) {
+	var f *failures
+
+	f.add(sdk.Instance{ID: "i1"}, errors.New("instance is stuck"))
+	f.hold(sdk.Network{ID: "n1"}, &ResourceError{ResourceType: "instance", ID: "i1"})
+
+	if _, ok := f.blocker(sdk.Network{ID: "n1"}); ok {
+		t.Fatalf("expected a nil failures to block nothing")
+	}
+
+	testutils.AssertNoErrorf(t, f.err(), "expected a nil failures to record nothing")
+}
@@ -0,0 +1,318 @@
+package civo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+)
+
+// resourceTypes is the list of every resource type dropkick knows how to
+// delete. The order in which they're declared is used to break ties when
+// more than one type is ready to be deleted, so the deletion order stays
+// stable between runs.
+var resourceTypes = []sdk.APIResource{
+	sdk.LoadBalancer{},
+	sdk.KubernetesCluster{},
+	sdk.Instance{},
+	sdk.Database{},
+	sdk.Snapshot{},
+	sdk.DiskImage{},
+	sdk.Volume{},
+	sdk.SSHKey{},
+	sdk.ReservedIP{},
+	sdk.ObjectStore{},
+	sdk.ObjectStoreCredential{},
+	sdk.Firewall{},
+	sdk.Network{},
+	sdk.DNSDomain{},
+}
+
+// dependencies declares, for every pair of resource types that reference each
+// other, which one must be deleted first. In Civo, certain resources won't
+// delete their dependencies (for example, deleting an Instance that is on a
+// Network won't delete the Network because it could be shared with other
+// Instances), and a resource can't be deleted while something else still
+// uses it. Adding a new resource type only requires declaring its edges here.
+var dependencies = []dependency{
+	// Load balancers tie to Kubernetes clusters and firewalls. The load
+	// balancers of a cluster are part of it.
+	partOf(edge(func(lb sdk.LoadBalancer, k sdk.KubernetesCluster) bool { return sameID(lb.ClusterID, k.ID) })),
+	edge(func(lb sdk.LoadBalancer, f sdk.Firewall) bool { return sameID(lb.FirewallID, f.ID) }),
+
+	// Kubernetes clusters own their node instances and volumes (PVCs), and
+	// use a firewall and a network.
+	edge(func(k sdk.KubernetesCluster, i sdk.Instance) bool {
+		return slices.ContainsFunc(k.Instances, func(ki sdk.Instance) bool { return sameID(ki.ID, i.ID) })
+	}),
+	edge(func(k sdk.KubernetesCluster, v sdk.Volume) bool { return sameID(v.ClusterID, k.ID) }),
+	edge(func(k sdk.KubernetesCluster, f sdk.Firewall) bool { return sameID(k.FirewallID, f.ID) }),
+	edge(func(k sdk.KubernetesCluster, n sdk.Network) bool { return sameID(k.NetworkID, n.ID) }),
+
+	// Instances might have volumes attached, and use a network, a firewall
+	// and an SSH key.
+	edge(func(i sdk.Instance, v sdk.Volume) bool { return sameID(v.InstanceID, i.ID) }),
+	edge(func(i sdk.Instance, n sdk.Network) bool { return sameID(i.NetworkID, n.ID) }),
+	edge(func(i sdk.Instance, f sdk.Firewall) bool { return sameID(i.FirewallID, f.ID) }),
+	edge(func(i sdk.Instance, s sdk.SSHKey) bool { return sameID(i.SSHKeyID, s.ID) }),
+
+	// Reserved IPs can't be deleted while they're assigned to an instance or
+	// a load balancer.
+	edge(func(i sdk.Instance, r sdk.ReservedIP) bool {
+		return r.AssignedTo.Type == "instance" && sameID(r.AssignedTo.ID, i.ID)
+	}),
+	edge(func(lb sdk.LoadBalancer, r sdk.ReservedIP) bool {
+		return r.AssignedTo.Type == "loadbalancer" && sameID(r.AssignedTo.ID, lb.ID)
+	}),
+
+	// Snapshots and the disk images instances were launched from are only
+	// deleted once the instances are gone.
+	edge(func(i sdk.Instance, s sdk.Snapshot) bool { return sameID(s.InstanceID, i.ID) }),
+	edge(func(i sdk.Instance, d sdk.DiskImage) bool { return sameID(i.SourceID, d.ID) }),
+
+	// Databases use a firewall and a network.
+	edge(func(d sdk.Database, f sdk.Firewall) bool { return sameID(d.FirewallID, f.ID) }),
+	edge(func(d sdk.Database, n sdk.Network) bool { return sameID(d.NetworkID, n.ID) }),
+
+	// Volumes live in a network.
+	edge(func(v sdk.Volume, n sdk.Network) bool { return sameID(v.NetworkID, n.ID) }),
+
+	// Deleting object stores leaves their credentials orphaned.
+	edge(func(o sdk.ObjectStore, c sdk.ObjectStoreCredential) bool {
+		return sameID(o.Credentials.ID, c.ID) || sameID(o.Credentials.CredentialID, c.ID)
+	}),
+
+	// Firewalls need to be gone before deleting their network.
+	edge(func(f sdk.Firewall, n sdk.Network) bool { return sameID(f.NetworkID, n.ID) }),
+}
+
+// dependency declares that resources of the blocker type must be deleted
+// before resources of the blocked type.
+type dependency struct {
+	blocker sdk.APIResource // The resource type to delete first.
+	blocked sdk.APIResource // The resource type to delete afterwards.
+
+	// linked reports whether a specific blocker resource holds up the
+	// deletion of a specific blocked resource.
+	linked func(blocker, blocked sdk.APIResource) bool
+
+	// part is set when the blocker resource is part of the blocked one, so
+	// keeping the blocked resource means keeping the blocker too.
+	part bool
+}
+
+// edge creates a dependency between the resource types B and D, where B must
+// be deleted before D. The linked function reports whether a given resource
+// of type B references, or is referenced by, a given resource of type D.
+func edge[B, D sdk.Resource](linked func(B, D) bool) dependency {
+	var (
+		blocker B
+		blocked D
+	)
+
+	return dependency{
+		blocker: blocker,
+		blocked: blocked,
+		linked: func(a, b sdk.APIResource) bool {
+			ba, ok := a.(B)
+			if !ok {
+				return false
+			}
+
+			db, ok := b.(D)
+			if !ok {
+				return false
+			}
+
+			return linked(ba, db)
+		},
+	}
+}
+
+// partOf marks a dependency as one where the blocker resource is part of
+// the blocked resource, like the load balancers of a Kubernetes cluster.
+func partOf(d dependency) dependency {
+	d.part = true
+	return d
+}
+
+// blocks reports whether a resource holds up the deletion of another
+// resource, according to the dependencies.
+func blocks(blocker, blocked sdk.APIResource) bool {
+	for _, d := range dependencies {
+		if d.blocker.GetResourceType() != blocker.GetResourceType() || d.blocked.GetResourceType() != blocked.GetResourceType() {
+			continue
+		}
+
+		if d.linked(blocker, blocked) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameID checks if two resource IDs are the same, ignoring empty IDs.
+func sameID(a, b string) bool {
+	return a != "" && a == b
+}
+
+// graph is a dependency graph of resource types.
+type graph struct {
+	nodes []sdk.APIResource
+	edges []dependency
+}
+
+// newGraph creates a dependency graph with the given resource types as nodes.
+// Edges pointing to resource types that aren't part of the nodes are ignored.
+// It returns an error if the dependencies form a cycle.
+func newGraph(nodes []sdk.APIResource, edges []dependency) (*graph, error) {
+	g := &graph{nodes: nodes}
+
+	for _, e := range edges {
+		if g.has(e.blocker) && g.has(e.blocked) {
+			g.edges = append(g.edges, e)
+		}
+	}
+
+	if _, err := g.order(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// has checks if the resource type is a node in the graph.
+func (g *graph) has(node sdk.APIResource) bool {
+	return slices.ContainsFunc(g.nodes, func(n sdk.APIResource) bool {
+		return n.GetResourceType() == node.GetResourceType()
+	})
+}
+
+// blockers returns the resource types that must be deleted before the given one.
+func (g *graph) blockers(node sdk.APIResource) []sdk.APIResource {
+	var blockers []sdk.APIResource
+
+	for _, e := range g.edges {
+		if e.blocked.GetResourceType() == node.GetResourceType() {
+			blockers = append(blockers, e.blocker)
+		}
+	}
+
+	return blockers
+}
+
+// ready checks if all the blockers of a node are in the done set.
+func (g *graph) ready(node sdk.APIResource, done map[string]bool) bool {
+	for _, blocker := range g.blockers(node) {
+		if !done[blocker.GetResourceType()] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// order returns the nodes of the graph in topological order, breaking ties
+// using the order in which the nodes were declared. It returns an error if
+// the dependencies form a cycle.
+func (g *graph) order() ([]sdk.APIResource, error) {
+	done := make(map[string]bool, len(g.nodes))
+	sorted := make([]sdk.APIResource, 0, len(g.nodes))
+
+	for len(sorted) < len(g.nodes) {
+		progress := false
+
+		for _, node := range g.nodes {
+			if done[node.GetResourceType()] || !g.ready(node, done) {
+				continue
+			}
+
+			done[node.GetResourceType()] = true
+			sorted = append(sorted, node)
+			progress = true
+			break
+		}
+
+		if !progress {
+			var stuck []string
+			for _, node := range g.nodes {
+				if !done[node.GetResourceType()] {
+					stuck = append(stuck, node.GetResourceType())
+				}
+			}
+
+			return nil, fmt.Errorf("dependency cycle between resource types: %s", strings.Join(stuck, ", "))
+		}
+	}
+
+	return sorted, nil
+}
+
+// walkResult is the outcome of processing a single node during a walk.
+type walkResult struct {
+	node sdk.APIResource
+	err  error
+}
+
+// walk calls fn for every node in the graph, only once all the blockers of
+// that node have been processed. Up to parallel nodes whose blockers are done
+// are processed at the same time; with a parallelism of 1, nodes are
+// processed in the same order returned by order. After the first error, no
+// new nodes are started, the context passed to the running ones is cancelled
+// and the error is returned once they finish.
+func (g *graph) walk(ctx context.Context, parallel int, fn func(context.Context, sdk.APIResource) error) error {
+	pending, err := g.order()
+	if err != nil {
+		return err
+	}
+
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		done     = make(map[string]bool, len(g.nodes))
+		results  = make(chan walkResult)
+		running  int
+		firstErr error
+	)
+
+	for {
+		// start every pending node that's ready, as long as there's capacity
+		for i := 0; firstErr == nil && i < len(pending) && running < parallel; {
+			node := pending[i]
+			if !g.ready(node, done) {
+				i++
+				continue
+			}
+
+			pending = slices.Delete(pending, i, i+1)
+			running++
+
+			go func() {
+				results <- walkResult{node: node, err: fn(ctx, node)}
+			}()
+		}
+
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+		done[res.node.GetResourceType()] = true
+
+		if res.err != nil && firstErr == nil {
+			firstErr = res.err
+			cancel()
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,118 @@
+package civo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+)
+
+func TestGraph(t *testing.T) {
+	t.Run("default graph is ordered like the historical nuke sequence", func(t *testing.T) {
+		g, err := newGraph(resourceTypes, dependencies)
+		testutils.AssertNoErrorf(t, err, "expected no error when building the default graph, got %v", err)
+
+		order, err := g.order()
+		testutils.AssertNoError(t, err)
+
+		expected := []string{
+			"load balancer",
+			"kubernetes cluster",
+			"instance",
+			"database",
+			"snapshot",
+			"disk image",
+			"volume",
+			"ssh key",
+			"reserved ip",
+			"object store",
+			"object store credential",
+			"firewall",
+			"network",
+			"dns domain",
+		}
+
+		testutils.AssertEqualf(t, len(order), len(expected), "expected %d resource types, got %d", len(expected), len(order))
+		for i, node := range order {
+			testutils.AssertEqualf(t, node.GetResourceType(), expected[i], "expected %q at position %d, got %q", expected[i], i, node.GetResourceType())
+		}
+	})
+
+	t.Run("cycles are detected", func(t *testing.T) {
+		_, err := newGraph(
+			[]sdk.APIResource{sdk.Instance{}, sdk.Network{}},
+			[]dependency{
+				edge(func(sdk.Instance, sdk.Network) bool { return true }),
+				edge(func(sdk.Network, sdk.Instance) bool { return true }),
+			},
+		)
+		testutils.AssertErrorf(t, err, "expected error when building a graph with a cycle")
+	})
+
+	t.Run("edges to unknown types are ignored", func(t *testing.T) {
+		g, err := newGraph([]sdk.APIResource{sdk.Network{}}, dependencies)
+		testutils.AssertNoError(t, err)
+		testutils.AssertEqual(t, len(g.blockers(sdk.Network{})), 0)
+	})
+
+	t.Run("parallel walk waits for blockers", func(t *testing.T) {
+		g, err := newGraph(resourceTypes, dependencies)
+		testutils.AssertNoError(t, err)
+
+		var (
+			mu   sync.Mutex
+			done = make(map[string]bool)
+		)
+
+		err = g.walk(context.Background(), 4, func(_ context.Context, node sdk.APIResource) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, blocker := range g.blockers(node) {
+				if !done[blocker.GetResourceType()] {
+					t.Errorf("%s started before its blocker %s finished", node.GetResourceType(), blocker.GetResourceType())
+				}
+			}
+
+			done[node.GetResourceType()] = true
+			return nil
+		})
+		testutils.AssertNoError(t, err)
+		testutils.AssertEqual(t, len(done), len(resourceTypes))
+	})
+
+	t.Run("walk stops after an error", func(t *testing.T) {
+		g, err := newGraph(resourceTypes, dependencies)
+		testutils.AssertNoError(t, err)
+
+		errExpected := errors.New("expected error")
+		calls := 0
+
+		err = g.walk(context.Background(), 1, func(_ context.Context, node sdk.APIResource) error {
+			calls++
+			return errExpected
+		})
+		testutils.AssertErrorEqual(t, errExpected, err)
+		testutils.AssertEqual(t, calls, 1)
+	})
+
+	t.Run("linked resources", func(t *testing.T) {
+		e := edge(func(i sdk.Instance, n sdk.Network) bool { return sameID(i.NetworkID, n.ID) })
+
+		testutils.AssertEqual(t, e.linked(sdk.Instance{NetworkID: "1"}, sdk.Network{ID: "1"}), true)
+		testutils.AssertEqual(t, e.linked(sdk.Instance{NetworkID: "1"}, sdk.Network{ID: "2"}), false)
+		testutils.AssertEqual(t, e.linked(sdk.Instance{}, sdk.Network{}), false)
+		testutils.AssertEqual(t, e.linked(sdk.Volume{}, sdk.Network{ID: "1"}), false)
+	})
+	t.Run("databases are deleted before their firewall and network", func(t *testing.T) {
+		db := sdk.Database{ID: "1", FirewallID: "fw", NetworkID: "net"}
+
+		testutils.AssertEqual(t, blocks(db, sdk.Firewall{ID: "fw"}), true)
+		testutils.AssertEqual(t, blocks(db, sdk.Network{ID: "net"}), true)
+		testutils.AssertEqual(t, blocks(db, sdk.Network{ID: "other"}), false)
+		testutils.AssertEqual(t, blocks(sdk.Firewall{ID: "fw"}, db), false)
+	})
+}
@@ -2,36 +2,175 @@ package civo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/konstructio/dropkick/internal/civo/sdk"
-	"github.com/konstructio/dropkick/internal/compare"
+	"github.com/konstructio/dropkick/internal/matcher"
 	"github.com/konstructio/dropkick/internal/outputwriter"
+	"github.com/konstructio/dropkick/internal/report"
 )
 
 // deleteIterator returns a function that can be used to iterate over resources.
 func (c *Civo) deleteIterator(ctx context.Context) func(sdk.APIResource) error {
 	return func(resource sdk.APIResource) error {
-		c.logger.Infof("found %s: name: %q - ID: %q", resource.GetResourceType(), resource.GetName(), resource.GetID())
+		log := c.resourceLogger(resource)
 
-		if c.nameFilter != "" && !compare.ContainsIgnoreCase(resource.GetName(), c.nameFilter) {
-			c.logger.Warnf("skipping %s %q: name does not match filter", resource.GetResourceType(), resource.GetName())
+		resourceTags, taggable := tagsOf(resource)
+		if taggable {
+			log.Infof("found %s: name: %q - ID: %q - tags: %q", resource.GetResourceType(), resource.GetName(), resource.GetID(), resourceTags)
+		} else {
+			log.Infof("found %s: name: %q - ID: %q", resource.GetResourceType(), resource.GetName(), resource.GetID())
+		}
+
+		if rule, ok := c.protect.Match(resource.GetResourceType(), resource.GetID(), resource.GetName()); ok {
+			c.protected(resource, rule)
+			c.kept.add(resource, rule)
 			return nil
 		}
 
-		if !c.nuke {
-			c.logger.Warnf("refusing to delete %s %q: nuke is not enabled", resource.GetResourceType(), resource.GetName())
+		if kept, ok := c.kept.linked(resource); ok {
+			c.protectedLink(resource, kept)
+			c.kept.add(resource, kept.rule)
+			return nil
+		}
+
+		if ok, reason := c.matchName(resource.GetName()); !ok {
+			c.skip(resource, reason)
+			return nil
+		}
+
+		if ok, reason := c.age.Match(resource.GetCreatedAt()); !ok {
+			c.skip(resource, reason)
+			return nil
+		}
+
+		if ok, reason := c.tags.Match(resourceTags, taggable); !ok {
+			c.skip(resource, reason)
+			return nil
+		}
+
+		if c.expiring && !c.isExpired(resource, resourceTags) {
 			return nil
 		}
 
-		c.logger.Infof("deleting %s %q", resource.GetResourceType(), resource.GetName())
+		if c.plan != nil {
+			log.Infof("planning deletion of %s %q", resource.GetResourceType(), resource.GetName())
+			reason := c.plan.reason(c.describeFilters())
+			c.plan.add(resource, reason)
+			c.record(resource, true, report.ActionPlanned, reason)
+			return nil
+		}
 
-		err := c.client.Delete(ctx, resource)
-		if err != nil {
-			return fmt.Errorf("unable to delete %s %q: %w", resource.GetResourceType(), resource.GetName(), err)
+		if !c.nuke {
+			log.Warnf("refusing to delete %s %q: nuke is not enabled", resource.GetResourceType(), resource.GetName())
+			c.record(resource, true, report.ActionRefused, "nuke is not enabled")
+			return nil
 		}
 
-		outputwriter.WriteStdoutf("deleted %s %q", resource.GetResourceType(), resource.GetName())
+		return c.deleteUnlessBlocked(ctx, resource)
+	}
+}
+
+// deleteUnlessBlocked deletes a resource, unless a resource it depends on failed
+// to be deleted earlier in the run. When keep going is enabled, a failure
+// is recorded instead of returned, so the run can continue.
+func (c *Civo) deleteUnlessBlocked(ctx context.Context, resource sdk.APIResource) error {
+	if failed, ok := c.failures.blocker(resource); ok {
+		c.skip(resource, "blocked by "+failed.describe())
+		c.failures.hold(resource, failed)
+		return nil
+	}
+
+	err := c.deleteResource(ctx, resource)
+
+	// A resource still present after the wait was deleted all the same,
+	// but it still holds up the resources depending on it.
+	var timeoutErr *DeletionTimeoutError
+
+	switch {
+	case errors.As(err, &timeoutErr):
+		c.record(resource, true, report.ActionPending, err.Error())
+	case err != nil:
+		c.record(resource, true, report.ActionFailed, err.Error())
+	default:
+		c.record(resource, true, report.ActionDeleted, "")
+	}
+
+	if err != nil && c.keepGoing && c.failures != nil {
+		c.resourceLogger(resource).Errorf("%s", err)
+		c.failures.add(resource, err)
 		return nil
 	}
+
+	return err
+}
+
+// trackFailures starts recording failures for a run. It returns a function
+// to call when the run ends, which stops recording and returns the run
+// error joined with every recorded failure.
+func (c *Civo) trackFailures() func(error) error {
+	c.failures = &failures{}
+
+	return func(err error) error {
+		recorded := c.failures.err()
+		c.failures = nil
+
+		return errors.Join(err, recorded)
+	}
+}
+
+// matchName checks a resource name against the name filter and the name
+// matcher. If the name isn't selected, it also returns the reason why.
+func (c *Civo) matchName(name string) (bool, string) {
+	if ok, reason := matcher.Contains(c.nameFilter).Match(name); !ok {
+		return false, reason
+	}
+
+	return c.names.Match(name)
+}
+
+// describeFilters returns a description of the resources selected by the
+// name filter, the name matcher, the age filter and the tag filter, or an
+// empty string if every resource is selected.
+func (c *Civo) describeFilters() string {
+	var parts []string
+
+	for _, f := range []fmt.Stringer{matcher.Contains(c.nameFilter), c.names, c.age, c.tags} {
+		if description := f.String(); description != "" {
+			parts = append(parts, description)
+		}
+	}
+
+	return strings.Join(parts, " and ")
+}
+
+// tagsOf returns the tags of a resource, and whether its type supports tags.
+func tagsOf(resource sdk.APIResource) ([]string, bool) {
+	tagged, ok := resource.(sdk.Tagged)
+	if !ok {
+		return nil, false
+	}
+
+	return tagged.GetTags(), true
+}
+
+// deleteResource deletes a single resource and, if other resource types
+// depend on it, waits for it to be gone. It's only reported as deleted once
+// the wait is over.
+func (c *Civo) deleteResource(ctx context.Context, resource sdk.APIResource) error {
+	c.resourceLogger(resource).Infof("deleting %s %q", resource.GetResourceType(), resource.GetName())
+
+	err := c.client.Delete(ctx, resource)
+	if err != nil {
+		return fmt.Errorf("unable to delete %s %q (ID: %q): %w", resource.GetResourceType(), resource.GetName(), resource.GetID(), err)
+	}
+
+	if err := c.waitForDeletion(ctx, resource); err != nil {
+		return err
+	}
+
+	outputwriter.WriteStdoutf("deleted %s %q", resource.GetResourceType(), resource.GetName())
+	return nil
 }
@@ -3,11 +3,17 @@ package civo
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/konstructio/dropkick/internal/age"
 	"github.com/konstructio/dropkick/internal/civo/sdk"
 	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
 	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/matcher"
+	"github.com/konstructio/dropkick/internal/protect"
+	"github.com/konstructio/dropkick/internal/tags"
 )
 
 func TestIterator(t *testing.T) {
@@ -96,6 +102,115 @@ func TestIterator(t *testing.T) {
 		testutils.AssertEqualf(t, false, deleteCalled, "expected delete to not be called, got %v", deleteCalled)
 	})
 
+	t.Run("delete should only be called for names selected by the name matcher", func(t *testing.T) {
+		var deleted []string
+
+		mock := &mockClient{
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				deleted = append(deleted, resource.GetName())
+				return nil
+			},
+		}
+
+		names, err := matcher.New(matcher.Options{
+			Regexes:  []string{`^ci-\d+-`},
+			NotGlobs: []string{"ci-*-keep"},
+		})
+		testutils.AssertNoErrorf(t, err, "expected no error creating the matcher, got %v", err)
+
+		c := &Civo{
+			client: mock,
+			logger: logger.None,
+			nuke:   true,
+			names:  names,
+		}
+
+		iterFunc := c.deleteIterator(context.Background())
+
+		for _, name := range []string{"ci-42-k3s", "ci-42-keep", "prod-k3s"} {
+			err := iterFunc(sdk.Instance{ID: name, Name: name})
+			testutils.AssertNoErrorf(t, err, "expected no error when calling iterator, got %v", err)
+		}
+
+		testutils.AssertEqualf(t, 1, len(deleted), "expected a single resource to be deleted, got %v", deleted)
+		testutils.AssertEqualf(t, "ci-42-k3s", deleted[0], "expected %q to be deleted, got %q", "ci-42-k3s", deleted[0])
+	})
+
+	t.Run("delete should only be called for resources selected by the age filter", func(t *testing.T) {
+		var deleted []string
+
+		mock := &mockClient{
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				deleted = append(deleted, resource.GetName())
+				return nil
+			},
+		}
+
+		filter, err := age.New(6*time.Hour, 0, age.MissingSkip)
+		testutils.AssertNoErrorf(t, err, "expected no error creating the age filter, got %v", err)
+
+		c := &Civo{
+			client: mock,
+			logger: logger.None,
+			nuke:   true,
+			age:    filter,
+		}
+
+		iterFunc := c.deleteIterator(context.Background())
+
+		instances := []sdk.Instance{
+			{ID: "1", Name: "leaked", CreatedAt: sdk.Timestamp{Time: time.Now().Add(-7 * 24 * time.Hour)}},
+			{ID: "2", Name: "running-ci-job", CreatedAt: sdk.Timestamp{Time: time.Now().Add(-5 * time.Minute)}},
+			{ID: "3", Name: "unknown-age"},
+		}
+
+		for _, instance := range instances {
+			err := iterFunc(instance)
+			testutils.AssertNoErrorf(t, err, "expected no error when calling iterator, got %v", err)
+		}
+
+		testutils.AssertEqualf(t, 1, len(deleted), "expected a single resource to be deleted, got %v", deleted)
+		testutils.AssertEqualf(t, "leaked", deleted[0], "expected %q to be deleted, got %q", "leaked", deleted[0])
+	})
+
+	t.Run("delete should only be called for resources selected by the tag filter", func(t *testing.T) {
+		var deleted []string
+
+		mock := &mockClient{
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				deleted = append(deleted, resource.GetName())
+				return nil
+			},
+		}
+
+		filter, err := tags.NewFilter([]string{"env=ci"}, []string{"keep"})
+		testutils.AssertNoErrorf(t, err, "expected no error creating the tag filter, got %v", err)
+
+		c := &Civo{
+			client: mock,
+			logger: logger.None,
+			nuke:   true,
+			tags:   filter,
+		}
+
+		iterFunc := c.deleteIterator(context.Background())
+
+		resources := []sdk.APIResource{
+			sdk.Instance{ID: "1", Name: "ci-instance", Tags: []string{"env=ci"}},
+			sdk.KubernetesCluster{ID: "2", Name: "kept-cluster", Tags: []string{"env=ci", "keep"}},
+			sdk.Instance{ID: "3", Name: "prod-instance", Tags: []string{"env=prod"}},
+			sdk.Volume{ID: "4", Name: "untaggable-volume"},
+		}
+
+		for _, resource := range resources {
+			err := iterFunc(resource)
+			testutils.AssertNoErrorf(t, err, "expected no error when calling iterator, got %v", err)
+		}
+
+		testutils.AssertEqualf(t, 1, len(deleted), "expected a single resource to be deleted, got %v", deleted)
+		testutils.AssertEqualf(t, "ci-instance", deleted[0], "expected %q to be deleted, got %q", "ci-instance", deleted[0])
+	})
+
 	t.Run("delete should return error if delete fails", func(t *testing.T) {
 		madeUpError := errors.New("made up!")
 
@@ -121,4 +236,36 @@ func TestIterator(t *testing.T) {
 		err := iterFunc(instance)
 		testutils.AssertErrorEqual(t, madeUpError, err)
 	})
+
+	t.Run("delete should not be called if the resource is protected", func(t *testing.T) {
+		deleteCalled := false
+
+		mock := &mockClient{
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				deleteCalled = true
+				return nil
+			},
+		}
+
+		rules, err := protect.Parse(strings.NewReader("name_globs: [\"test-*\"]"))
+		testutils.AssertNoError(t, err)
+
+		c := &Civo{
+			client:  mock,
+			logger:  logger.None,
+			nuke:    true,
+			protect: rules,
+		}
+
+		iterFunc := c.deleteIterator(context.Background())
+
+		instance := sdk.Instance{
+			ID:   "123",
+			Name: "test-instance",
+		}
+
+		err = iterFunc(instance)
+		testutils.AssertNoErrorf(t, err, "expected no error when calling iterator, got %v", err)
+		testutils.AssertEqualf(t, false, deleteCalled, "expected delete to not be called, got %v", deleteCalled)
+	})
 }
@@ -10,57 +10,74 @@ import (
 // NukeEverything deletes all resources associated with the Civo account it's
 // targeting in the given Civo region.
 func (c *Civo) NukeEverything(ctx context.Context) error {
-	// The order in which these resources are deleted matter. We start with those
-	// resources that have dependencies. In Civo, certain resources won't delete
-	// their dependencies (for example, deleting an Instance that is on a Network
-	// won't delete the Network because it could be shared with other Instances).
-	// So we delete first those resources that could cascade delete other resources.
-
-	// We start by deleting all Load Balancers, which tie to Firewalls and Kubernetes Clusters.
-	if err := c.client.Each(ctx, sdk.LoadBalancer{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete load balancers: %w", err)
+	// The order in which these resources are deleted matter, so it's derived
+	// from the dependency graph: a resource type is only processed once every
+	// resource type that could be holding it up has been processed.
+	g, err := newGraph(resourceTypes, dependencies)
+	if err != nil {
+		return fmt.Errorf("unable to build dependency graph: %w", err)
 	}
 
-	// Kubernetes clusters depend on volumes (PVCs), but we will delete the PVCs
-	// after the clusters to clean them all.
-	if err := c.client.Each(ctx, sdk.KubernetesCluster{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete Kubernetes clusters: %w", err)
-	}
+	c.warnSkippedBlockers()
+	end := c.trackFailures()
 
-	// Then we delete the instances, which might also have volumes attached.
-	if err := c.client.Each(ctx, sdk.Instance{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete instances: %w", err)
+	done, err := c.trackProtected(ctx)
+	if err != nil {
+		return end(err)
 	}
+	defer done()
 
-	// Now we delete the volumes.
-	if err := c.client.Each(ctx, sdk.Volume{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete volumes: %w", err)
-	}
+	// Every resource type whose blockers are done is processed at the same
+	// time, but listing and deleting resources take their slot from the same
+	// semaphore, so no more than the configured concurrency ever run at the
+	// same time. Types that aren't selected are still part of the graph, so
+	// the order between the selected ones is kept even when they depend on
+	// each other through a skipped type.
+	slots := newSlots(c.concurrency)
 
-	// And we also delete the SSH keys, including those now orphaned by instances.
-	if err := c.client.Each(ctx, sdk.SSHKey{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete SSH keys: %w", err)
-	}
+	return end(g.walk(ctx, len(resourceTypes), func(ctx context.Context, resource sdk.APIResource) error {
+		if !c.selects(resource) {
+			return nil
+		}
 
-	// Then we delete object stores, which will leave their credentials orphaned.
-	if err := c.client.Each(ctx, sdk.ObjectStore{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete object stores: %w", err)
-	}
+		// Only resources with tags can carry an expiry, so there's no point
+		// in listing the others.
+		if _, taggable := tagsOf(resource); c.expiring && !taggable {
+			return nil
+		}
 
-	// Now we delete the object store credentials.
-	if err := c.client.Each(ctx, sdk.ObjectStoreCredential{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete object store credentials: %w", err)
-	}
+		var resources []sdk.APIResource
 
-	// Firewalls are deleted next, since we need them to be gone before deleting networks.
-	if err := c.client.Each(ctx, sdk.Firewall{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete firewalls: %w", err)
-	}
+		select {
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck // the caller knows about the context it provided
+		case slots <- struct{}{}:
+		}
 
-	// And finally, we delete the networks.
-	if err := c.client.Each(ctx, sdk.Network{}, c.deleteIterator(ctx)); err != nil {
-		return fmt.Errorf("unable to delete networks: %w", err)
-	}
+		err := c.client.Each(ctx, resource, func(r sdk.APIResource) error {
+			resources = append(resources, r)
+			return nil
+		})
+		<-slots
+
+		if err != nil {
+			err = fmt.Errorf("unable to list resources of type %q: %w", resource.GetResourceType(), err)
+			if c.keepGoing {
+				c.logger.Errorf("%s", err)
+				c.failures.add(resource, err)
+				return nil
+			}
+
+			return err
+		}
+
+		err = runShared(ctx, slots, resources, func(ctx context.Context, r sdk.APIResource) error {
+			return c.deleteIterator(ctx)(r)
+		})
+		if err != nil {
+			return fmt.Errorf("unable to delete resources of type %q: %w", resource.GetResourceType(), err)
+		}
 
-	return nil
+		return nil
+	}))
 }
@@ -2,9 +2,12 @@ package civo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand/v2"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/konstructio/dropkick/internal/civo/sdk"
 	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
@@ -18,20 +21,25 @@ func Test_NukeEverything(t *testing.T) {
 			lbList                    = generator[sdk.LoadBalancer](rand.IntN(10) + 1)
 			kubernetesList            = generator[sdk.KubernetesCluster](rand.IntN(10) + 1)
 			instanceList              = generator[sdk.Instance](rand.IntN(10) + 1)
+			databaseList              = generator[sdk.Database](rand.IntN(10) + 1)
+			snapshotList              = generator[sdk.Snapshot](rand.IntN(10) + 1)
+			diskImageList             = generator[sdk.DiskImage](rand.IntN(10) + 1)
 			volumeList                = generator[sdk.Volume](rand.IntN(10) + 1)
 			sshKeyList                = generator[sdk.SSHKey](rand.IntN(10) + 1)
+			reservedIPList            = generator[sdk.ReservedIP](rand.IntN(10) + 1)
 			objectStoreList           = generator[sdk.ObjectStore](rand.IntN(10) + 1)
 			objectStoreCredentialList = generator[sdk.ObjectStoreCredential](rand.IntN(10) + 1)
 			firewallList              = generator[sdk.Firewall](rand.IntN(10) + 1)
 			networkList               = generator[sdk.Network](rand.IntN(10) + 1)
+			dnsDomainList             = generator[sdk.DNSDomain](rand.IntN(10) + 1)
 		)
 
 		// count the number of calls to "Each"
 		callCount := 0
 
-		// there are 9 resources supported to be deleted
+		// there are 14 resources supported to be deleted
 		// in the civo API defined by us
-		numberOfResources := 9
+		numberOfResources := 14
 
 		mock := &mockClient{
 			fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
@@ -43,10 +51,18 @@ func Test_NukeEverything(t *testing.T) {
 					return runEach(kubernetesList, fn)
 				case sdk.Instance:
 					return runEach(instanceList, fn)
+				case sdk.Database:
+					return runEach(databaseList, fn)
+				case sdk.Snapshot:
+					return runEach(snapshotList, fn)
+				case sdk.DiskImage:
+					return runEach(diskImageList, fn)
 				case sdk.Volume:
 					return runEach(volumeList, fn)
 				case sdk.SSHKey:
 					return runEach(sshKeyList, fn)
+				case sdk.ReservedIP:
+					return runEach(reservedIPList, fn)
 				case sdk.ObjectStore:
 					return runEach(objectStoreList, fn)
 				case sdk.ObjectStoreCredential:
@@ -55,6 +71,8 @@ func Test_NukeEverything(t *testing.T) {
 					return runEach(firewallList, fn)
 				case sdk.Network:
 					return runEach(networkList, fn)
+				case sdk.DNSDomain:
+					return runEach(dnsDomainList, fn)
 				default:
 					t.Fatalf("unexpected resource type: %T", resource)
 				}
@@ -78,6 +96,96 @@ func Test_NukeEverything(t *testing.T) {
 		testutils.AssertEqualf(t, callCount, numberOfResources, "expected all resources to be deleted, got %d", callCount)
 	})
 
+	t.Run("never deletes more resources at once than the concurrency", func(t *testing.T) {
+		var inFlight, peak atomic.Int32
+
+		mock := &mockClient{
+			fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+				switch resource.(type) {
+				case sdk.SSHKey:
+					return runEach(generator[sdk.SSHKey](10), fn)
+				case sdk.ObjectStore:
+					return runEach(generator[sdk.ObjectStore](10), fn)
+				case sdk.DNSDomain:
+					return runEach(generator[sdk.DNSDomain](10), fn)
+				}
+
+				return nil
+			},
+
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+
+				for {
+					prev := peak.Load()
+					if current <= prev || peak.CompareAndSwap(prev, current) {
+						break
+					}
+				}
+
+				time.Sleep(time.Millisecond)
+				return nil
+			},
+		}
+
+		c := &Civo{
+			client:      mock,
+			logger:      logger.None,
+			nuke:        true,
+			concurrency: 3,
+		}
+
+		err := c.NukeEverything(context.Background())
+		testutils.AssertNoErrorf(t, err, "expected no error when calling NukeEverything, got %v", err)
+
+		if got := peak.Load(); got > 3 {
+			t.Fatalf("expected at most 3 resources deleted at the same time, got %d", got)
+		}
+	})
+
+	t.Run("deletes independent resource types at the same time", func(t *testing.T) {
+		domainDeleting := make(chan struct{})
+
+		mock := &mockClient{
+			fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+				switch resource.(type) {
+				case sdk.SSHKey:
+					return runEach(generator[sdk.SSHKey](1), fn)
+				case sdk.DNSDomain:
+					return runEach(generator[sdk.DNSDomain](1), fn)
+				}
+
+				return nil
+			},
+
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				switch resource.(type) {
+				case sdk.DNSDomain:
+					close(domainDeleting)
+				case sdk.SSHKey:
+					select {
+					case <-domainDeleting:
+					case <-time.After(5 * time.Second):
+						return errors.New("the DNS domain wasn't deleted while the SSH key was")
+					}
+				}
+
+				return nil
+			},
+		}
+
+		c := &Civo{
+			client:      mock,
+			logger:      logger.None,
+			nuke:        true,
+			concurrency: 2,
+		}
+
+		err := c.NukeEverything(context.Background())
+		testutils.AssertNoErrorf(t, err, "expected no error when calling NukeEverything, got %v", err)
+	})
+
 	t.Run("error when deleting resources", func(t *testing.T) {
 		// While I would love to shrink this test, each function uses a generic
 		// parameter which cannot be passed programatically unless we also use
@@ -136,6 +244,54 @@ func Test_NukeEverything(t *testing.T) {
 					return nil
 				},
 			},
+			{
+				name: "error when deleting databases",
+				fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+					if _, ok := resource.(sdk.Database); ok {
+						return runEach(generator[sdk.Database](rand.IntN(10)+1), fn)
+					}
+					return nil
+				},
+				fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+					if _, ok := resource.(sdk.Database); ok {
+						return fmt.Errorf("expected error when deleting %T", resource)
+					}
+
+					return nil
+				},
+			},
+			{
+				name: "error when deleting snapshots",
+				fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+					if _, ok := resource.(sdk.Snapshot); ok {
+						return runEach(generator[sdk.Snapshot](rand.IntN(10)+1), fn)
+					}
+					return nil
+				},
+				fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+					if _, ok := resource.(sdk.Snapshot); ok {
+						return fmt.Errorf("expected error when deleting %T", resource)
+					}
+
+					return nil
+				},
+			},
+			{
+				name: "error when deleting disk images",
+				fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+					if _, ok := resource.(sdk.DiskImage); ok {
+						return runEach(generator[sdk.DiskImage](rand.IntN(10)+1), fn)
+					}
+					return nil
+				},
+				fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+					if _, ok := resource.(sdk.DiskImage); ok {
+						return fmt.Errorf("expected error when deleting %T", resource)
+					}
+
+					return nil
+				},
+			},
 			{
 				name: "error when deleting volumes",
 				fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
@@ -168,6 +324,22 @@ func Test_NukeEverything(t *testing.T) {
 					return nil
 				},
 			},
+			{
+				name: "error when deleting reserved ips",
+				fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+					if _, ok := resource.(sdk.ReservedIP); ok {
+						return runEach(generator[sdk.ReservedIP](rand.IntN(10)+1), fn)
+					}
+					return nil
+				},
+				fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+					if _, ok := resource.(sdk.ReservedIP); ok {
+						return fmt.Errorf("expected error when deleting %T", resource)
+					}
+
+					return nil
+				},
+			},
 			{
 				name: "error when deleting object stores",
 				fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
@@ -229,6 +401,22 @@ func Test_NukeEverything(t *testing.T) {
 						return fmt.Errorf("expected error when deleting %T", resource)
 					}
 
+					return nil
+				},
+			},
+			{
+				name: "error when deleting dns domains",
+				fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+					if _, ok := resource.(sdk.DNSDomain); ok {
+						return runEach(generator[sdk.DNSDomain](rand.IntN(10)+1), fn)
+					}
+					return nil
+				},
+				fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+					if _, ok := resource.(sdk.DNSDomain); ok {
+						return fmt.Errorf("expected error when deleting %T", resource)
+					}
+
 					return nil
 				},
 			},
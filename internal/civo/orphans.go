@@ -2,7 +2,11 @@ package civo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"slices"
+	"strings"
 
 	"github.com/konstructio/dropkick/internal/civo/sdk"
 )
@@ -12,96 +16,229 @@ import (
 // process encounters any issues. The resources targeted by this function are:
 // - Load Balancers
 // - Volumes
+// - Reserved IPs
+// - Instance snapshots
 // - Object store credentials
 // - SSH keys
 // - Networks
 // - Firewalls
+// - DNS records
+//
+// Resource types that aren't selected are left alone.
 func (c *Civo) NukeOrphanedResources(ctx context.Context) error {
+	end := c.trackFailures()
+	return end(c.nukeOrphanedResources(ctx))
+}
+
+// nukeOrphanedResources finds and deletes the orphaned resources of every
+// selected type, in the order they depend on each other. With keep going
+// enabled, a type that fails to be listed is recorded as a failure, and
+// the run continues with the types that don't need it.
+func (c *Civo) nukeOrphanedResources(ctx context.Context) error {
+	// the resource types that failed to be listed, so the types relying on
+	// them to find what's orphaned are skipped
+	unavailable := make(map[string]bool)
+
+	// listFailed records a failure to list a resource type when keep
+	// going is enabled, or returns it otherwise
+	listFailed := func(resource sdk.APIResource, err error) error {
+		if !c.keepGoing {
+			return err
+		}
+
+		c.logger.Errorf("%s", err)
+		c.failures.add(resource, err)
+		unavailable[resource.GetResourceType()] = true
+		return nil
+	}
+
+	// canFind checks if every resource type needed to find the orphaned
+	// resources of the given type was listed, warning about the first one
+	// that wasn't
+	canFind := func(resource sdk.APIResource, needs ...sdk.APIResource) bool {
+		for _, need := range needs {
+			if unavailable[need.GetResourceType()] {
+				c.logger.Warnf("skipping orphaned resources of type %q: resources of type %q failed to be listed", resource.GetResourceType(), need.GetResourceType())
+				return false
+			}
+		}
+
+		return true
+	}
+
 	// fetch all nodes first, we'll need them to check for orphaned resources
 	c.logger.Infof("fetching all instances")
 	nodes, err := c.client.GetInstances(ctx)
 	if err != nil {
-		return fmt.Errorf("unable to fetch instances: %w", err)
+		if err := listFailed(sdk.Instance{}, fmt.Errorf("unable to fetch instances: %w", err)); err != nil {
+			return err
+		}
 	}
 
 	// fetch also all volumes to check for networks connected to them
 	c.logger.Infof("fetching all volumes")
 	volumes, err := c.client.GetVolumes(ctx)
 	if err != nil {
-		return fmt.Errorf("unable to fetch volumes: %w", err)
+		if err := listFailed(sdk.Volume{}, fmt.Errorf("unable to fetch volumes: %w", err)); err != nil {
+			return err
+		}
 	}
 
-	// fetch orphaned load balancers
-	orphanedLBs, err := c.getOrphanedLoadBalancers(ctx)
+	// fetch all clusters to find the resources left behind by deleted ones
+	c.logger.Infof("fetching all kubernetes clusters")
+	clusters, err := c.client.GetKubernetesClusters(ctx)
 	if err != nil {
-		return fmt.Errorf("unable to fetch orphaned load balancers: %w", err)
+		if err := listFailed(sdk.KubernetesCluster{}, fmt.Errorf("unable to fetch kubernetes clusters: %w", err)); err != nil {
+			return err
+		}
 	}
 
-	if err := nukeSlice(ctx, c, orphanedLBs); err != nil {
-		return fmt.Errorf("unable to delete orphaned load balancers: %w", err)
+	// fetch the load balancers and databases, which can use firewalls
+	c.logger.Infof("fetching all load balancers")
+	lbs, err := c.client.GetLoadBalancers(ctx)
+	if err != nil {
+		if err := listFailed(sdk.LoadBalancer{}, fmt.Errorf("unable to fetch load balancers: %w", err)); err != nil {
+			return err
+		}
+	}
+
+	c.logger.Infof("fetching all databases")
+	databases, err := c.client.GetDatabases(ctx)
+	if err != nil {
+		if err := listFailed(sdk.Database{}, fmt.Errorf("unable to fetch databases: %w", err)); err != nil {
+			return err
+		}
+	}
+
+	c.warnSkippedBlockers()
+
+	// fetch orphaned load balancers
+	if c.selects(sdk.LoadBalancer{}) && canFind(sdk.LoadBalancer{}, sdk.LoadBalancer{}, sdk.KubernetesCluster{}) {
+		orphanedLBs := c.getOrphanedLoadBalancers(lbs, clusters)
+		if err := nukeSlice(ctx, c, orphanedLBs); err != nil {
+			return fmt.Errorf("unable to delete orphaned load balancers: %w", err)
+		}
 	}
 
 	// fetch orphaned volumes
-	orphanedVolumes := c.getOrphanedVolumes(volumes)
-	if err := nukeSlice(ctx, c, orphanedVolumes); err != nil {
-		return fmt.Errorf("unable to delete orphaned volumes: %w", err)
+	if c.selects(sdk.Volume{}) && canFind(sdk.Volume{}, sdk.Volume{}, sdk.KubernetesCluster{}) {
+		orphanedVolumes := c.getOrphanedVolumes(volumes, clusters)
+		if err := nukeSlice(ctx, c, orphanedVolumes); err != nil {
+			return fmt.Errorf("unable to delete orphaned volumes: %w", err)
+		}
 	}
 
-	// fetch orphaned object store credentials
-	orphanedObjectStoreCredentials, err := c.getOrphanedObjectStoreCredentials(ctx)
-	if err != nil {
-		return fmt.Errorf("unable to fetch orphaned object store credentials: %w", err)
+	// fetch orphaned reserved IPs
+	if c.selects(sdk.ReservedIP{}) {
+		orphanedIPs, err := c.getOrphanedReservedIPs(ctx)
+		if err != nil {
+			if err := listFailed(sdk.ReservedIP{}, fmt.Errorf("unable to fetch orphaned reserved IPs: %w", err)); err != nil {
+				return err
+			}
+		}
+
+		if err := nukeSlice(ctx, c, orphanedIPs); err != nil {
+			return fmt.Errorf("unable to delete orphaned reserved IPs: %w", err)
+		}
 	}
 
-	if err := nukeSlice(ctx, c, orphanedObjectStoreCredentials); err != nil {
-		return fmt.Errorf("unable to delete orphaned object store credentials: %w", err)
+	// fetch orphaned snapshots
+	if c.selects(sdk.Snapshot{}) && canFind(sdk.Snapshot{}, sdk.Instance{}) {
+		orphanedSnapshots, err := c.getOrphanedSnapshots(ctx, nodes)
+		if err != nil {
+			if err := listFailed(sdk.Snapshot{}, fmt.Errorf("unable to fetch orphaned snapshots: %w", err)); err != nil {
+				return err
+			}
+		}
+
+		if err := nukeSlice(ctx, c, orphanedSnapshots); err != nil {
+			return fmt.Errorf("unable to delete orphaned snapshots: %w", err)
+		}
 	}
 
-	// fetch orphaned SSH keys
-	orphanedSSHKeys, err := c.getOrphanedSSHKeys(ctx, nodes)
-	if err != nil {
-		return fmt.Errorf("unable to fetch orphaned SSH keys: %w", err)
+	// fetch orphaned object store credentials
+	if c.selects(sdk.ObjectStoreCredential{}) {
+		orphanedObjectStoreCredentials, err := c.getOrphanedObjectStoreCredentials(ctx)
+		if err != nil {
+			if err := listFailed(sdk.ObjectStoreCredential{}, fmt.Errorf("unable to fetch orphaned object store credentials: %w", err)); err != nil {
+				return err
+			}
+		}
+
+		if err := nukeSlice(ctx, c, orphanedObjectStoreCredentials); err != nil {
+			return fmt.Errorf("unable to delete orphaned object store credentials: %w", err)
+		}
 	}
 
-	if err := nukeSlice(ctx, c, orphanedSSHKeys); err != nil {
-		return fmt.Errorf("unable to delete orphaned SSH keys: %w", err)
+	// fetch orphaned SSH keys
+	if c.selects(sdk.SSHKey{}) && canFind(sdk.SSHKey{}, sdk.Instance{}) {
+		orphanedSSHKeys, err := c.getOrphanedSSHKeys(ctx, nodes)
+		if err != nil {
+			if err := listFailed(sdk.SSHKey{}, fmt.Errorf("unable to fetch orphaned SSH keys: %w", err)); err != nil {
+				return err
+			}
+		}
+
+		if err := nukeSlice(ctx, c, orphanedSSHKeys); err != nil {
+			return fmt.Errorf("unable to delete orphaned SSH keys: %w", err)
+		}
 	}
 
 	// fetch orphaned networks
-	orphanedNetworks, err := c.getOrphanedNetworks(ctx, nodes, volumes)
-	if err != nil {
-		return fmt.Errorf("unable to fetch orphaned networks: %w", err)
-	}
+	if c.selects(sdk.Network{}) && canFind(sdk.Network{}, sdk.Instance{}, sdk.Volume{}) {
+		orphanedNetworks, err := c.getOrphanedNetworks(ctx, nodes, volumes)
+		if err != nil {
+			if err := listFailed(sdk.Network{}, fmt.Errorf("unable to fetch orphaned networks: %w", err)); err != nil {
+				return err
+			}
+		}
 
-	if err := nukeSlice(ctx, c, orphanedNetworks); err != nil {
-		return fmt.Errorf("unable to delete orphaned networks: %w", err)
+		if err := nukeSlice(ctx, c, orphanedNetworks); err != nil {
+			return fmt.Errorf("unable to delete orphaned networks: %w", err)
+		}
 	}
 
 	// fetch orphaned firewalls
-	orphanedFirewalls, err := c.getOrphanedFirewalls(ctx)
-	if err != nil {
-		return fmt.Errorf("unable to fetch orphaned firewalls: %w", err)
-	}
+	if c.selects(sdk.Firewall{}) && canFind(sdk.Firewall{}, sdk.Instance{}, sdk.KubernetesCluster{}, sdk.LoadBalancer{}, sdk.Database{}) {
+		orphanedFirewalls, err := c.getOrphanedFirewalls(ctx, firewallUsers(nodes, clusters, lbs, databases))
+		if err != nil {
+			if err := listFailed(sdk.Firewall{}, fmt.Errorf("unable to fetch orphaned firewalls: %w", err)); err != nil {
+				return err
+			}
+		}
 
-	if err := nukeSlice(ctx, c, orphanedFirewalls); err != nil {
-		return fmt.Errorf("unable to delete orphaned firewalls: %w", err)
+		if err := nukeSlice(ctx, c, orphanedFirewalls); err != nil {
+			return fmt.Errorf("unable to delete orphaned firewalls: %w", err)
+		}
 	}
 
-	return nil
-}
+	// fetch orphaned DNS records, selected with the DNS domains since
+	// records can't be deleted on their own in nuke everything mode
+	if c.selects(sdk.DNSDomain{}) {
+		orphanedRecords, err := c.getOrphanedDNSRecords(ctx)
+		if err != nil {
+			if err := listFailed(sdk.DNSDomain{}, fmt.Errorf("unable to fetch orphaned DNS records: %w", err)); err != nil {
+				return err
+			}
+		}
 
-// nukeSlice deletes all resources in the provided slice. It returns an error if
-// the deletion process encounters any issues.
-func nukeSlice[T sdk.Resource](ctx context.Context, c *Civo, resources []T) error {
-	for _, resource := range resources {
-		if err := c.deleteIterator(ctx)(resource); err != nil {
-			return err
+		if err := nukeSlice(ctx, c, orphanedRecords); err != nil {
+			return fmt.Errorf("unable to delete orphaned DNS records: %w", err)
 		}
 	}
 
 	return nil
 }
 
+// nukeSlice deletes all resources in the provided slice, up to the configured
+// concurrency at the same time. It returns an error if the deletion process
+// encounters any issues.
+func nukeSlice[T sdk.Resource](ctx context.Context, c *Civo, resources []T) error {
+	return runPool(ctx, c.concurrency, resources, func(ctx context.Context, resource T) error {
+		return c.deleteIterator(ctx)(resource)
+	})
+}
+
 // getOrphanedObjectStoreCredentials fetches all object store then the object
 // store credentials and compares them against each other. If a credential is
 // not used by any store, it is considered orphaned. It returns an error if the
@@ -130,7 +267,7 @@ func (c *Civo) getOrphanedObjectStoreCredentials(ctx context.Context) ([]sdk.Obj
 		for _, objectStore := range objectStores {
 			// on a GET request for object stores, only
 			if objectStore.Credentials.ID == credential.CredentialID {
-				c.logger.Warnf("skipping object store credential %q: it is associated with the object store with ID %q", credential.Name, objectStore.ID)
+				c.skip(credential, fmt.Sprintf("it is associated with the object store with ID %q", objectStore.ID))
 				found = true
 				break
 			}
@@ -146,24 +283,27 @@ func (c *Civo) getOrphanedObjectStoreCredentials(ctx context.Context) ([]sdk.Obj
 	return orphanedCredentials, nil
 }
 
-func (c *Civo) getOrphanedLoadBalancers(ctx context.Context) ([]sdk.LoadBalancer, error) {
-	c.logger.Infof("listing load balancers")
-
-	lbs, err := c.client.GetLoadBalancers(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("unable to list load balancers: %w", err)
-	}
-
+// getOrphanedLoadBalancers returns the load balancers that aren't associated
+// with a firewall, and the ones whose Kubernetes cluster no longer exists.
+func (c *Civo) getOrphanedLoadBalancers(lbs []sdk.LoadBalancer, clusters []sdk.KubernetesCluster) []sdk.LoadBalancer {
 	// iterate over all load balancers and check if they are associated with any nodes
 	orphanedLBs := make([]sdk.LoadBalancer, 0, len(lbs))
 	for _, lb := range lbs {
 		if lb.ClusterID != "" {
-			c.logger.Warnf("skipping load balancer %q: it is associated with the cluster with ID %q", lb.Name, lb.ClusterID)
+			if clusterExists(clusters, lb.ClusterID) {
+				c.skip(lb, fmt.Sprintf("it is associated with the cluster with ID %q", lb.ClusterID))
+				continue
+			}
+
+			// the firewall of a load balancer created by a cluster is
+			// created with it, so it doesn't keep the load balancer in use
+			c.logger.Infof("found orphaned load balancer %q - ID: %q: its cluster with ID %q no longer exists", lb.Name, lb.ID, lb.ClusterID)
+			orphanedLBs = append(orphanedLBs, lb)
 			continue
 		}
 
 		if lb.FirewallID != "" {
-			c.logger.Warnf("skipping load balancer %q: it is associated with the firewall with ID %q", lb.Name, lb.FirewallID)
+			c.skip(lb, fmt.Sprintf("it is associated with the firewall with ID %q", lb.FirewallID))
 			continue
 		}
 
@@ -172,18 +312,25 @@ func (c *Civo) getOrphanedLoadBalancers(ctx context.Context) ([]sdk.LoadBalancer
 	}
 
 	c.logger.Infof("found %d load balancers, %d of which are orphaned", len(lbs), len(orphanedLBs))
-	return orphanedLBs, nil
+	return orphanedLBs
 }
 
 // getOrphanedVolumes fetches all volumes that are not attached to any node
-// instance instead of relying if they are referenced by a node instance. It
-// returns an error if the fetching process encounters any issues.
-func (c *Civo) getOrphanedVolumes(volumes []sdk.Volume) []sdk.Volume {
+// instance instead of relying if they are referenced by a node instance, and
+// the volumes of Kubernetes clusters that no longer exist. It returns an
+// error if the fetching process encounters any issues.
+func (c *Civo) getOrphanedVolumes(volumes []sdk.Volume, clusters []sdk.KubernetesCluster) []sdk.Volume {
 	newVolumeList := make([]sdk.Volume, 0, len(volumes))
 
 	for _, volume := range volumes {
+		if volume.ClusterID != "" && !clusterExists(clusters, volume.ClusterID) {
+			c.logger.Infof("found orphaned volume %q - ID: %q: its cluster with ID %q no longer exists", volume.Name, volume.ID, volume.ClusterID)
+			newVolumeList = append(newVolumeList, volume)
+			continue
+		}
+
 		if volume.Status == "attached" {
-			c.logger.Warnf("skipping volume %q: it is attached to the node instance with ID %q", volume.Name, volume.InstanceID)
+			c.skip(volume, fmt.Sprintf("it is attached to the node instance with ID %q", volume.InstanceID))
 			continue
 		}
 
@@ -195,6 +342,66 @@ func (c *Civo) getOrphanedVolumes(volumes []sdk.Volume) []sdk.Volume {
 	return newVolumeList
 }
 
+// clusterExists checks if a Kubernetes cluster with the given ID is in the
+// list of clusters.
+func clusterExists(clusters []sdk.KubernetesCluster, id string) bool {
+	return slices.ContainsFunc(clusters, func(k sdk.KubernetesCluster) bool { return sameID(k.ID, id) })
+}
+
+// getOrphanedReservedIPs fetches all reserved IPs and returns the ones that
+// aren't assigned to any instance or load balancer. It returns an error if
+// the fetching process encounters any issues.
+func (c *Civo) getOrphanedReservedIPs(ctx context.Context) ([]sdk.ReservedIP, error) {
+	c.logger.Infof("listing reserved IPs")
+
+	ips, err := c.client.GetReservedIPs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list reserved IPs: %w", err)
+	}
+
+	orphanedIPs := make([]sdk.ReservedIP, 0, len(ips))
+	for _, ip := range ips {
+		if ip.AssignedTo.ID != "" {
+			c.skip(ip, fmt.Sprintf("it is assigned to the %s with ID %q", ip.AssignedTo.Type, ip.AssignedTo.ID))
+			continue
+		}
+
+		c.logger.Infof("found orphaned reserved IP %q (%s) - ID: %q", ip.Name, ip.IP, ip.ID)
+		orphanedIPs = append(orphanedIPs, ip)
+	}
+
+	c.logger.Infof("found %d reserved IPs, %d of which are orphaned", len(ips), len(orphanedIPs))
+	return orphanedIPs, nil
+}
+
+// getOrphanedSnapshots fetches all instance snapshots then compares them
+// against the provided list of nodes: a snapshot whose instance no longer
+// exists is considered orphaned. Like every orphaned resource, it's only
+// deleted if it's also selected by the age filter. It returns an error if
+// the fetching process encounters any issues.
+func (c *Civo) getOrphanedSnapshots(ctx context.Context, nodes []sdk.Instance) ([]sdk.Snapshot, error) {
+	c.logger.Infof("listing snapshots")
+
+	snapshots, err := c.client.GetSnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list snapshots: %w", err)
+	}
+
+	orphanedSnapshots := make([]sdk.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if slices.ContainsFunc(nodes, func(node sdk.Instance) bool { return sameID(snapshot.InstanceID, node.ID) }) {
+			c.skip(snapshot, fmt.Sprintf("its node instance with ID %q still exists", snapshot.InstanceID))
+			continue
+		}
+
+		c.logger.Infof("found orphaned snapshot %q (instance %q is gone) - ID: %q", snapshot.Name, snapshot.InstanceID, snapshot.ID)
+		orphanedSnapshots = append(orphanedSnapshots, snapshot)
+	}
+
+	c.logger.Infof("found %d snapshots, %d of which are orphaned", len(snapshots), len(orphanedSnapshots))
+	return orphanedSnapshots, nil
+}
+
 // getOrphanedSSHKeys fetches all SSH keys then compares them against the
 // provided list of nodes to determine if they are associated with any of
 // them. It returns an error if the fetching process encounters any issues.
@@ -214,7 +421,7 @@ func (c *Civo) getOrphanedSSHKeys(ctx context.Context, nodes []sdk.Instance) ([]
 		// iterate through the nodes finding if they use the current key
 		for _, node := range nodes {
 			if node.SSHKeyID == key.ID {
-				c.logger.Warnf("skipping SSH key %q: it is associated with the node instance with ID %q", key.Name, node.ID)
+				c.skip(key, fmt.Sprintf("it is associated with the node instance with ID %q", node.ID))
 				found = true
 				break
 			}
@@ -248,14 +455,14 @@ func (c *Civo) getOrphanedNetworks(ctx context.Context, nodes []sdk.Instance, vo
 
 		// check if network name is "default", if so, skip it
 		if network.Default {
-			c.logger.Warnf("skipping network %q: it is the default network", network.Name)
+			c.skip(network, "it is the default network")
 			continue
 		}
 
 		// iterate through the nodes finding if they use the current network
 		for _, node := range nodes {
 			if node.NetworkID == network.ID {
-				c.logger.Warnf("skipping network %q: it is associated with the node instance with ID %q", network.Name, node.ID)
+				c.skip(network, fmt.Sprintf("it is associated with the node instance with ID %q", node.ID))
 				found = true
 				break
 			}
@@ -263,8 +470,8 @@ func (c *Civo) getOrphanedNetworks(ctx context.Context, nodes []sdk.Instance, vo
 
 		// iterate through the volumes finding if they use the current network
 		for _, volume := range volumes {
-			if volume.NetworkID == network.ID {
-				c.logger.Warnf("skipping network %q: it is associated with the volume with ID %q", network.Name, volume.ID)
+			if !found && volume.NetworkID == network.ID {
+				c.skip(network, fmt.Sprintf("it is associated with the volume with ID %q", volume.ID))
 				found = true
 				break
 			}
@@ -280,10 +487,12 @@ func (c *Civo) getOrphanedNetworks(ctx context.Context, nodes []sdk.Instance, vo
 	return orphanedNetworks, nil
 }
 
-// getOrphanedFirewalls fetches all firewalls then checks if they are associated
-// with any node instance, cluster, or load balancer. It returns an error if the
+// getOrphanedFirewalls fetches all firewalls then checks if they are used by
+// any node instance, cluster, load balancer or database, as listed in users,
+// which maps the ID of every firewall in use to what uses it. The firewalls
+// of the default network are never orphaned. It returns an error if the
 // fetching process encounters any issues.
-func (c *Civo) getOrphanedFirewalls(ctx context.Context) ([]sdk.Firewall, error) {
+func (c *Civo) getOrphanedFirewalls(ctx context.Context, users map[string]string) ([]sdk.Firewall, error) {
 	c.logger.Infof("listing firewalls")
 
 	firewalls, err := c.client.GetFirewalls(ctx)
@@ -291,26 +500,205 @@ func (c *Civo) getOrphanedFirewalls(ctx context.Context) ([]sdk.Firewall, error)
 		return nil, fmt.Errorf("unable to list firewalls: %w", err)
 	}
 
-	// iterate over all firewalls and check if they are associated with any nodes
+	// the firewalls of the default network are kept, like the network
+	networks, err := c.client.GetNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list networks: %w", err)
+	}
+
+	defaultNetworks := make(map[string]bool)
+	for _, network := range networks {
+		if network.Default {
+			defaultNetworks[network.ID] = true
+		}
+	}
+
+	// iterate over all firewalls and check if anything uses them
 	orphanedFirewalls := make([]sdk.Firewall, 0, len(firewalls))
 	for _, firewall := range firewalls {
-		if firewall.ClusterCount > 0 || firewall.InstanceCount > 0 || firewall.LoadBalancerCount > 0 {
-			c.logger.Warnf(
-				"skipping firewall %q: it is associated with %d clusters, %d instances, and %d load balancers",
-				firewall.Name, firewall.ClusterCount, firewall.InstanceCount, firewall.LoadBalancerCount,
-			)
+		if defaultNetworks[firewall.NetworkID] {
+			c.skip(firewall, fmt.Sprintf("it belongs to the default network with ID %q", firewall.NetworkID))
 			continue
 		}
 
-		if firewall.NetworkID != "" {
-			c.logger.Warnf("skipping firewall %q: it is associated with the network with ID %q", firewall.Name, firewall.NetworkID)
+		if user, ok := users[firewall.ID]; ok {
+			c.skip(firewall, "it is used by "+user)
 			continue
 		}
 
-		c.logger.Infof("found orphaned firewall %q - ID: %q", firewall.Name, firewall.ID)
+		c.logger.Infof("found orphaned firewall %q - ID: %q: no node instance, cluster, load balancer or database uses it", firewall.Name, firewall.ID)
 		orphanedFirewalls = append(orphanedFirewalls, firewall)
 	}
 
 	c.logger.Infof("found %d firewalls, %d of which are orphaned", len(firewalls), len(orphanedFirewalls))
 	return orphanedFirewalls, nil
 }
+
+// firewallUsers maps the ID of every firewall used by one of the provided
+// resources to the first resource found using it. Resources deleted earlier
+// in the same run are still listed, so their firewall is kept until the
+// next run.
+func firewallUsers(nodes []sdk.Instance, clusters []sdk.KubernetesCluster, lbs []sdk.LoadBalancer, databases []sdk.Database) map[string]string {
+	users := make(map[string]string)
+
+	add := func(firewallID, user string) {
+		if _, ok := users[firewallID]; firewallID != "" && !ok {
+			users[firewallID] = user
+		}
+	}
+
+	for _, node := range nodes {
+		add(node.FirewallID, fmt.Sprintf("the node instance with ID %q", node.ID))
+	}
+
+	for _, cluster := range clusters {
+		add(cluster.FirewallID, fmt.Sprintf("the cluster with ID %q", cluster.ID))
+	}
+
+	for _, lb := range lbs {
+		add(lb.FirewallID, fmt.Sprintf("the load balancer with ID %q", lb.ID))
+	}
+
+	for _, database := range databases {
+		add(database.FirewallID, fmt.Sprintf("the database with ID %q", database.ID))
+	}
+
+	return users
+}
+
+// getOrphanedDNSRecords fetches the records of every DNS domain and returns
+// the A and CNAME records whose target no longer exists: CNAME records whose
+// target doesn't resolve, and records pointing at IP addresses no instance
+// or load balancer of the account uses, unless WithKeepExternalDNSRecords
+// keeps them. Other record types are never orphaned. It returns an error if the fetching
+// process encounters any issues.
+func (c *Civo) getOrphanedDNSRecords(ctx context.Context) ([]sdk.DNSRecord, error) {
+	c.logger.Infof("listing DNS domains")
+
+	domains, err := c.client.GetDNSDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list DNS domains: %w", err)
+	}
+
+	var (
+		total  int
+		owners map[string]string
+	)
+
+	orphanedRecords := make([]sdk.DNSRecord, 0)
+
+	for _, domain := range domains {
+		records, err := c.client.GetDNSRecords(ctx, domain.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list DNS records of domain %q: %w", domain.Name, err)
+		}
+
+		// the IP addresses in use are only needed once there are records
+		// to check, since listing them means going through every region
+		if owners == nil && len(records) > 0 {
+			if owners, err = c.dnsTargetOwners(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		total += len(records)
+
+		for _, record := range records {
+			orphaned, reason := c.dnsRecordTarget(ctx, record, owners)
+			if !orphaned {
+				c.skip(record, reason)
+				continue
+			}
+
+			c.logger.Infof("found orphaned DNS record %q of domain %q - ID: %q: %s", record.Name, domain.Name, record.ID, reason)
+			orphanedRecords = append(orphanedRecords, record)
+		}
+	}
+
+	c.logger.Infof("found %d DNS records, %d of which are orphaned", total, len(orphanedRecords))
+	return orphanedRecords, nil
+}
+
+// dnsTargetOwners maps the IP addresses of every instance and load balancer
+// of the account to what uses them. A reserved IP only counts once it's
+// assigned, through the public IP of what it's assigned to. DNS domains
+// aren't scoped to a region, so the resources of every region are included.
+func (c *Civo) dnsTargetOwners(ctx context.Context) (map[string]string, error) {
+	c.logger.Infof("listing the IP addresses in use in every region")
+
+	regions, err := c.client.GetRegions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list regions: %w", err)
+	}
+
+	owners := make(map[string]string)
+	add := func(ip, owner string) {
+		if ip != "" {
+			owners[ip] = owner
+		}
+	}
+
+	for _, region := range regions {
+		client := c.client.InRegion(region.Code)
+
+		nodes, err := client.GetInstances(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list instances in region %q: %w", region.Code, err)
+		}
+
+		lbs, err := client.GetLoadBalancers(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list load balancers in region %q: %w", region.Code, err)
+		}
+
+		for _, node := range nodes {
+			add(node.PublicIP, fmt.Sprintf("the node instance with ID %q in region %q", node.ID, region.Code))
+			add(node.PrivateIP, fmt.Sprintf("the node instance with ID %q in region %q", node.ID, region.Code))
+		}
+
+		for _, lb := range lbs {
+			add(lb.PublicIP, fmt.Sprintf("the load balancer with ID %q in region %q", lb.ID, region.Code))
+		}
+	}
+
+	return owners, nil
+}
+
+// dnsRecordTarget checks whether a DNS record points to one of the IP
+// addresses in owners, and returns whether the record is orphaned and why.
+func (c *Civo) dnsRecordTarget(ctx context.Context, record sdk.DNSRecord, owners map[string]string) (bool, string) {
+	var addrs []string
+
+	switch strings.ToUpper(record.Type) {
+	case "A":
+		addrs = []string{record.Value}
+	case "CNAME":
+		target := strings.TrimSuffix(record.Value, ".")
+
+		resolved, err := c.resolver.LookupHost(ctx, target)
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				return true, fmt.Sprintf("its target %q no longer resolves", target)
+			}
+
+			return false, fmt.Sprintf("unable to resolve its target %q: %s", target, err)
+		}
+
+		addrs = resolved
+	default:
+		return false, fmt.Sprintf("%s records are not checked", record.Type)
+	}
+
+	for _, addr := range addrs {
+		if owner, ok := owners[addr]; ok {
+			return false, fmt.Sprintf("it points to %s, used by %s", addr, owner)
+		}
+	}
+
+	if c.keepExtDNS {
+		return false, fmt.Sprintf("it points to %s, which no instance or load balancer uses, and external targets are kept", strings.Join(addrs, ", "))
+	}
+
+	return true, fmt.Sprintf("%s is not used by any instance or load balancer", strings.Join(addrs, ", "))
+}
@@ -1,10 +1,15 @@
 package civo
 
 import (
+	"bytes"
 	"context"
+	"net"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/konstructio/dropkick/internal/age"
 	"github.com/konstructio/dropkick/internal/civo/sdk"
 	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
 	"github.com/konstructio/dropkick/internal/logger"
@@ -88,6 +93,20 @@ func Test_NukeOrphanedResources(t *testing.T) {
 		Name: "test-firewall-2",
 	}}
 
+	clusters := []sdk.KubernetesCluster{{
+		ID:   "1",
+		Name: "test-cluster-1",
+	}}
+
+	reservedips := []sdk.ReservedIP{{
+		ID:         "1",
+		Name:       "test-reservedip-1",
+		AssignedTo: sdk.IPAssignee{ID: "1", Type: "instance"}, // assigned to an existing instance
+	}, {
+		ID:   "2",
+		Name: "test-reservedip-2",
+	}}
+
 	mock := &mockClient{
 		fnGetInstances:              func(ctx context.Context) ([]sdk.Instance, error) { return instances, nil },
 		fnGetVolumes:                func(ctx context.Context) ([]sdk.Volume, error) { return volumes, nil },
@@ -97,6 +116,11 @@ func Test_NukeOrphanedResources(t *testing.T) {
 		fnGetSSHKeys:                func(ctx context.Context) ([]sdk.SSHKey, error) { return sshkeys, nil },
 		fnGetNetworks:               func(ctx context.Context) ([]sdk.Network, error) { return networks, nil },
 		fnGetFirewalls:              func(ctx context.Context) ([]sdk.Firewall, error) { return firewalls, nil },
+		fnGetReservedIPs:            func(ctx context.Context) ([]sdk.ReservedIP, error) { return reservedips, nil },
+		fnGetDNSDomains:             func(ctx context.Context) ([]sdk.DNSDomain, error) { return nil, nil },
+		fnGetKubernetesClusters:     func(ctx context.Context) ([]sdk.KubernetesCluster, error) { return clusters, nil },
+		fnGetSnapshots:              func(ctx context.Context) ([]sdk.Snapshot, error) { return nil, nil },
+		fnGetDatabases:              func(ctx context.Context) ([]sdk.Database, error) { return nil, nil },
 	}
 
 	civo := &Civo{
@@ -107,3 +131,273 @@ func Test_NukeOrphanedResources(t *testing.T) {
 	err := civo.NukeOrphanedResources(context.Background())
 	testutils.AssertNoErrorf(t, err, "expected no error when calling NukeOrphanedResources, got %v", err)
 }
+
+func Test_getOrphanedReservedIPs(t *testing.T) {
+	reservedips := []sdk.ReservedIP{
+		{ID: "1", Name: "assigned-to-instance", AssignedTo: sdk.IPAssignee{ID: "10", Type: "instance"}},
+		{ID: "2", Name: "assigned-to-loadbalancer", AssignedTo: sdk.IPAssignee{ID: "20", Type: "loadbalancer"}},
+		{ID: "3", Name: "unassigned"},
+	}
+
+	mock := &mockClient{
+		fnGetReservedIPs: func(ctx context.Context) ([]sdk.ReservedIP, error) { return reservedips, nil },
+	}
+
+	var buf bytes.Buffer
+	civo := &Civo{client: mock, logger: logger.New(&buf)}
+
+	orphaned, err := civo.getOrphanedReservedIPs(context.Background())
+	testutils.AssertNoErrorf(t, err, "expected no error when fetching orphaned reserved IPs, got %v", err)
+	testutils.AssertEqualf(t, len(orphaned), 1, "expected 1 orphaned reserved IP, got %d", len(orphaned))
+	testutils.AssertEqual(t, orphaned[0].ID, "3")
+
+	if !strings.Contains(buf.String(), `skipping reserved ip "assigned-to-loadbalancer": it is assigned to the loadbalancer with ID "20"`) {
+		t.Fatalf("expected the assigned reserved IP to be skipped, got:\n%s", buf.String())
+	}
+}
+
+// fakeResolver resolves hosts from a fixed map, and fails as if the host
+// didn't exist for any other host.
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	addrs, ok := f[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	return addrs, nil
+}
+
+func Test_getOrphanedDNSRecords(t *testing.T) {
+	// DNS domains are shared by every region, so the addresses of every
+	// region are in use
+	regions := map[string]*mockClient{
+		"lon1": {
+			fnGetInstances: func(ctx context.Context) ([]sdk.Instance, error) {
+				return []sdk.Instance{{ID: "1", PublicIP: "192.0.2.1"}}, nil
+			},
+			fnGetLoadBalancers: func(ctx context.Context) ([]sdk.LoadBalancer, error) {
+				return []sdk.LoadBalancer{{ID: "1", PublicIP: "192.0.2.10"}}, nil
+			},
+		},
+		"fra1": {
+			fnGetInstances: func(ctx context.Context) ([]sdk.Instance, error) {
+				return []sdk.Instance{{ID: "2", PublicIP: "192.0.2.20"}}, nil
+			},
+			fnGetLoadBalancers: func(ctx context.Context) ([]sdk.LoadBalancer, error) {
+				return []sdk.LoadBalancer{{ID: "2", PublicIP: "192.0.2.30"}}, nil
+			},
+			fnGetReservedIPs: func(ctx context.Context) ([]sdk.ReservedIP, error) {
+				t.Fatal("expected reserved IPs not to be listed, since only assigned ones count")
+				return nil, nil
+			},
+		},
+	}
+
+	mock := &mockClient{
+		fnGetDNSDomains: func(ctx context.Context) ([]sdk.DNSDomain, error) {
+			return []sdk.DNSDomain{{ID: "d1", Name: "example.com"}}, nil
+		},
+		fnGetDNSRecords: func(ctx context.Context, domainID string) ([]sdk.DNSRecord, error) {
+			testutils.AssertEqual(t, domainID, "d1")
+
+			return []sdk.DNSRecord{
+				{ID: "1", DomainID: "d1", Name: "instance", Type: "A", Value: "192.0.2.1"},
+				{ID: "2", DomainID: "d1", Name: "unowned", Type: "A", Value: "192.0.2.2"},
+				{ID: "3", DomainID: "d1", Name: "lb", Type: "CNAME", Value: "lb.example.net."},
+				{ID: "4", DomainID: "d1", Name: "dead-lb", Type: "CNAME", Value: "gone.example.net"},
+				{ID: "5", DomainID: "d1", Name: "elsewhere", Type: "CNAME", Value: "elsewhere.example.net"},
+				{ID: "6", DomainID: "d1", Name: "mail", Type: "MX", Value: "mail.example.net"},
+				{ID: "7", DomainID: "d1", Name: "fra1-instance", Type: "A", Value: "192.0.2.20"},
+				{ID: "8", DomainID: "d1", Name: "fra1-lb", Type: "CNAME", Value: "fra1.example.net"},
+				{ID: "9", DomainID: "d1", Name: "fra1-reserved", Type: "A", Value: "192.0.2.40"},
+			}, nil
+		},
+		fnGetRegions: func(ctx context.Context) ([]sdk.Region, error) {
+			return []sdk.Region{{Code: "lon1"}, {Code: "fra1"}}, nil
+		},
+		fnInRegion: func(region string) Client {
+			return regions[region]
+		},
+	}
+
+	resolver := fakeResolver{
+		"lb.example.net":        {"192.0.2.10"},
+		"fra1.example.net":      {"192.0.2.30"},
+		"elsewhere.example.net": {"198.51.100.1"},
+	}
+
+	orphanedIDs := func(records []sdk.DNSRecord) string {
+		ids := make([]string, 0, len(records))
+		for _, record := range records {
+			ids = append(ids, record.ID)
+		}
+
+		return strings.Join(ids, ",")
+	}
+
+	t.Run("records whose target no instance or load balancer uses are orphaned", func(t *testing.T) {
+		var buf bytes.Buffer
+		civo := &Civo{client: mock, logger: logger.New(&buf), region: "lon1", resolver: resolver}
+
+		orphaned, err := civo.getOrphanedDNSRecords(context.Background())
+		testutils.AssertNoErrorf(t, err, "expected no error when fetching orphaned DNS records, got %v", err)
+		testutils.AssertEqualf(t, orphanedIDs(orphaned), "2,4,5,9", "unexpected orphaned DNS records: %v", orphaned)
+
+		for _, want := range []string{
+			`skipping dns record "instance": it points to 192.0.2.1, used by the node instance with ID "1" in region "lon1"`,
+			`skipping dns record "lb": it points to 192.0.2.10, used by the load balancer with ID "1" in region "lon1"`,
+			`skipping dns record "fra1-instance": it points to 192.0.2.20, used by the node instance with ID "2" in region "fra1"`,
+			`skipping dns record "fra1-lb": it points to 192.0.2.30, used by the load balancer with ID "2" in region "fra1"`,
+			`found orphaned DNS record "fra1-reserved" of domain "example.com" - ID: "9": 192.0.2.40 is not used by any instance or load balancer`,
+			`found orphaned DNS record "unowned" of domain "example.com" - ID: "2": 192.0.2.2 is not used by any instance or load balancer`,
+			`found orphaned DNS record "elsewhere" of domain "example.com" - ID: "5": 198.51.100.1 is not used by any instance or load balancer`,
+			`its target "gone.example.net" no longer resolves`,
+			`skipping dns record "mail": MX records are not checked`,
+		} {
+			if !strings.Contains(buf.String(), want) {
+				t.Fatalf("expected the log to contain %q, got:\n%s", want, buf.String())
+			}
+		}
+	})
+
+	t.Run("records pointing at external addresses are kept when asked to", func(t *testing.T) {
+		var buf bytes.Buffer
+		civo := &Civo{client: mock, logger: logger.New(&buf), region: "lon1", resolver: resolver, keepExtDNS: true}
+
+		orphaned, err := civo.getOrphanedDNSRecords(context.Background())
+		testutils.AssertNoErrorf(t, err, "expected no error when fetching orphaned DNS records, got %v", err)
+		testutils.AssertEqualf(t, orphanedIDs(orphaned), "4", "unexpected orphaned DNS records: %v", orphaned)
+
+		want := `skipping dns record "elsewhere": it points to 198.51.100.1, which no instance or load balancer uses, and external targets are kept`
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("expected the log to contain %q, got:\n%s", want, buf.String())
+		}
+	})
+}
+
+func Test_getOrphanedSnapshots(t *testing.T) {
+	instances := []sdk.Instance{{ID: "1", Name: "test-instance-1"}}
+
+	now := time.Now()
+	snapshots := []sdk.Snapshot{
+		{ID: "1", Name: "live-instance", InstanceID: "1", CreatedAt: sdk.Timestamp{Time: now.Add(-48 * time.Hour)}},
+		{ID: "2", Name: "old-gone-instance", InstanceID: "2", CreatedAt: sdk.Timestamp{Time: now.Add(-48 * time.Hour)}},
+		{ID: "3", Name: "new-gone-instance", InstanceID: "3", CreatedAt: sdk.Timestamp{Time: now.Add(-time.Hour)}},
+	}
+
+	var deleted []string
+
+	mock := &mockClient{
+		fnGetSnapshots: func(ctx context.Context) ([]sdk.Snapshot, error) { return snapshots, nil },
+		fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+			deleted = append(deleted, resource.GetID())
+			return nil
+		},
+	}
+
+	filter, err := age.New(24*time.Hour, 0, age.MissingSkip)
+	testutils.AssertNoError(t, err)
+
+	civo := &Civo{client: mock, logger: logger.None, nuke: true, age: filter, concurrency: 1}
+
+	orphaned, err := civo.getOrphanedSnapshots(context.Background(), instances)
+	testutils.AssertNoErrorf(t, err, "expected no error when fetching orphaned snapshots, got %v", err)
+	testutils.AssertEqualf(t, len(orphaned), 2, "expected 2 orphaned snapshots, got %d", len(orphaned))
+
+	// the age filter still applies to orphaned snapshots
+	err = nukeSlice(context.Background(), civo, orphaned)
+	testutils.AssertNoError(t, err)
+	testutils.AssertEqualf(t, strings.Join(deleted, ","), "2", "expected only the old orphaned snapshot to be deleted, got %v", deleted)
+}
+
+func Test_getOrphanedClusterResources(t *testing.T) {
+	clusters := []sdk.KubernetesCluster{{ID: "live", Name: "live-cluster"}}
+
+	t.Run("load balancers of deleted clusters are orphaned", func(t *testing.T) {
+		lbs := []sdk.LoadBalancer{
+			{ID: "1", Name: "live-cluster-lb", ClusterID: "live", FirewallID: "fw-1"},
+			{ID: "2", Name: "gone-cluster-lb", ClusterID: "gone", FirewallID: "fw-2"},
+			{ID: "3", Name: "firewalled-lb", FirewallID: "fw-3"},
+		}
+
+		var buf bytes.Buffer
+		civo := &Civo{logger: logger.New(&buf)}
+
+		orphaned := civo.getOrphanedLoadBalancers(lbs, clusters)
+		testutils.AssertEqualf(t, len(orphaned), 1, "expected 1 orphaned load balancer, got %d", len(orphaned))
+		testutils.AssertEqual(t, orphaned[0].ID, "2")
+
+		if !strings.Contains(buf.String(), `found orphaned load balancer "gone-cluster-lb" - ID: "2": its cluster with ID "gone" no longer exists`) {
+			t.Fatalf("expected the reason to be logged, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("volumes of deleted clusters are orphaned", func(t *testing.T) {
+		volumes := []sdk.Volume{
+			{ID: "1", Name: "live-cluster-pvc", ClusterID: "live", Status: "attached"},
+			{ID: "2", Name: "gone-cluster-pvc", ClusterID: "gone", Status: "attached"},
+			{ID: "3", Name: "attached-volume", Status: "attached"},
+		}
+
+		var buf bytes.Buffer
+		civo := &Civo{logger: logger.New(&buf)}
+
+		orphaned := civo.getOrphanedVolumes(volumes, clusters)
+		testutils.AssertEqualf(t, len(orphaned), 1, "expected 1 orphaned volume, got %d", len(orphaned))
+		testutils.AssertEqual(t, orphaned[0].ID, "2")
+
+		if !strings.Contains(buf.String(), `found orphaned volume "gone-cluster-pvc" - ID: "2": its cluster with ID "gone" no longer exists`) {
+			t.Fatalf("expected the reason to be logged, got:\n%s", buf.String())
+		}
+	})
+}
+
+func Test_getOrphanedFirewalls(t *testing.T) {
+	users := firewallUsers(
+		[]sdk.Instance{{ID: "i-1", FirewallID: "fw-instance"}},
+		[]sdk.KubernetesCluster{{ID: "k-1", FirewallID: "fw-cluster"}},
+		[]sdk.LoadBalancer{{ID: "lb-1", FirewallID: "fw-lb"}},
+		[]sdk.Database{{ID: "db-1", FirewallID: "fw-database"}},
+	)
+
+	mock := &mockClient{
+		fnGetFirewalls: func(ctx context.Context) ([]sdk.Firewall, error) {
+			return []sdk.Firewall{
+				{ID: "fw-instance", Name: "instance-firewall", NetworkID: "n-1"},
+				{ID: "fw-cluster", Name: "cluster-firewall", NetworkID: "n-1"},
+				{ID: "fw-lb", Name: "lb-firewall", NetworkID: "n-1"},
+				{ID: "fw-database", Name: "database-firewall", NetworkID: "n-1"},
+				{ID: "fw-unused", Name: "unused-firewall", NetworkID: "n-1"},
+				{ID: "fw-default", Name: "default-firewall", NetworkID: "n-default"},
+			}, nil
+		},
+		fnGetNetworks: func(ctx context.Context) ([]sdk.Network, error) {
+			return []sdk.Network{{ID: "n-default", Default: true}, {ID: "n-1"}}, nil
+		},
+	}
+
+	var buf bytes.Buffer
+	civo := &Civo{client: mock, logger: logger.New(&buf)}
+
+	orphaned, err := civo.getOrphanedFirewalls(context.Background(), users)
+	testutils.AssertNoErrorf(t, err, "expected no error when fetching orphaned firewalls, got %v", err)
+
+	// a firewall in a network is still orphaned if nothing uses it
+	testutils.AssertEqualf(t, len(orphaned), 1, "expected 1 orphaned firewall, got %d", len(orphaned))
+	testutils.AssertEqual(t, orphaned[0].ID, "fw-unused")
+
+	for _, want := range []string{
+		`skipping firewall "instance-firewall": it is used by the node instance with ID "i-1"`,
+		`skipping firewall "cluster-firewall": it is used by the cluster with ID "k-1"`,
+		`skipping firewall "lb-firewall": it is used by the load balancer with ID "lb-1"`,
+		`skipping firewall "database-firewall": it is used by the database with ID "db-1"`,
+		`found orphaned firewall "unused-firewall" - ID: "fw-unused": no node instance, cluster, load balancer or database uses it`,
+		`skipping firewall "default-firewall": it belongs to the default network with ID "n-default"`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("expected the log to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
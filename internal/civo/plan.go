@@ -0,0 +1,378 @@
+package civo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+)
+
+// planVersion is the version of the plan file format. It's bumped whenever
+// the format changes in a way older versions of dropkick can't understand.
+const planVersion = 1
+
+// Plan is a reviewable list of resources that dropkick would delete. It's
+// created by Civo.Plan and can later be applied with Civo.ApplyPlan, which
+// deletes exactly the resources in the plan and nothing else.
+type Plan struct {
+	Version     int               `json:"version"`
+	Provider    string            `json:"provider"`
+	Region      string            `json:"region"`
+	OrphansOnly bool              `json:"orphans_only"`
+	KeepExtDNS  bool              `json:"keep_external_dns_records,omitempty"` // whether DNS records pointing at addresses outside the account were kept
+	CreatedAt   time.Time         `json:"created_at"`
+	Resources   []PlannedResource `json:"resources"`
+
+	mu        sync.Mutex        // guards Resources while the plan is being built
+	resources []sdk.APIResource // the planned resources, in the same order as Resources, unknown for plans read from a file
+}
+
+// PlannedResource is a single resource recorded in a Plan.
+type PlannedResource struct {
+	Type   string            `json:"type"`
+	ID     string            `json:"id"` // the key returned by sdk.ResourceKey, which is the ID for most types
+	Name   string            `json:"name"`
+	Region string            `json:"region"`
+	Reason string            `json:"reason"`
+	State  map[string]string `json:"state,omitempty"` // the fields that decide whether the resource is deleted, as returned by resourceState
+}
+
+// add records a resource in the plan.
+func (p *Plan) add(resource sdk.APIResource, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Resources = append(p.Resources, PlannedResource{
+		Type:   resource.GetResourceType(),
+		ID:     sdk.ResourceKey(resource),
+		Name:   resource.GetName(),
+		Region: p.Region,
+		Reason: reason,
+		State:  resourceState(resource),
+	})
+	p.resources = append(p.resources, resource)
+}
+
+// resourceState returns the fields of a resource that decide whether it's
+// deleted: its tags, which filters and expiry rely on, and whatever ties it
+// to other resources, which decides whether it's orphaned or blocked.
+func resourceState(resource sdk.APIResource) map[string]string {
+	state := make(map[string]string)
+
+	if resourceTags, ok := tagsOf(resource); ok {
+		sorted := slices.Clone(resourceTags)
+		slices.Sort(sorted)
+		state["tags"] = strings.Join(sorted, ",")
+	}
+
+	switch r := resource.(type) {
+	case sdk.Instance:
+		state["network_id"] = r.NetworkID
+		state["firewall_id"] = r.FirewallID
+		state["ssh_key_id"] = r.SSHKeyID
+		state["source_id"] = r.SourceID
+	case sdk.KubernetesCluster:
+		state["network_id"] = r.NetworkID
+		state["firewall_id"] = r.FirewallID
+	case sdk.LoadBalancer:
+		state["cluster_id"] = r.ClusterID
+		state["firewall_id"] = r.FirewallID
+	case sdk.Volume:
+		state["status"] = r.Status
+		state["instance_id"] = r.InstanceID
+		state["cluster_id"] = r.ClusterID
+		state["network_id"] = r.NetworkID
+	case sdk.Database:
+		state["network_id"] = r.NetworkID
+		state["firewall_id"] = r.FirewallID
+	case sdk.Firewall:
+		state["network_id"] = r.NetworkID
+		state["instance_count"] = strconv.Itoa(r.InstanceCount)
+		state["cluster_count"] = strconv.Itoa(r.ClusterCount)
+		state["load_balancer_count"] = strconv.Itoa(r.LoadBalancerCount)
+	case sdk.ReservedIP:
+		state["assigned_to"] = r.AssignedTo.Type + "/" + r.AssignedTo.ID
+	case sdk.Snapshot:
+		state["instance_id"] = r.InstanceID
+	case sdk.ObjectStore:
+		state["credential_id"] = r.Credentials.ID
+	case sdk.DNSRecord:
+		state["type"] = r.Type
+		state["value"] = r.Value
+	}
+
+	if len(state) == 0 {
+		return nil
+	}
+
+	return state
+}
+
+// stateChanges describes every field that differs between the state of a
+// resource recorded in a plan and its current state, sorted by field.
+func stateChanges(planned, current map[string]string) []string {
+	fields := slices.Sorted(maps.Keys(current))
+	for field := range planned {
+		if _, ok := current[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+
+	var changes []string
+	for _, field := range fields {
+		if planned[field] != current[field] {
+			changes = append(changes, fmt.Sprintf("its %s changed from %q to %q", field, planned[field], current[field]))
+		}
+	}
+
+	return changes
+}
+
+// Blocks returns the indices of the planned resources that can't be
+// deleted while the planned resource at index i is kept, because they
+// depend on it. It returns nil for plans read from a file, since the
+// details needed to find the dependencies aren't recorded.
+func (p *Plan) Blocks(i int) []int {
+	if i < 0 || i >= len(p.resources) {
+		return nil
+	}
+
+	var blocked []int
+	for j, resource := range p.resources {
+		if j != i && blocks(p.resources[i], resource) {
+			blocked = append(blocked, j)
+		}
+	}
+
+	return blocked
+}
+
+// Subset returns a copy of the plan with only the planned resources at the
+// given indices, kept in the order they were planned.
+func (p *Plan) Subset(indices []int) *Plan {
+	keep := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		keep[i] = true
+	}
+
+	subset := &Plan{
+		Version:     p.Version,
+		Provider:    p.Provider,
+		Region:      p.Region,
+		OrphansOnly: p.OrphansOnly,
+		KeepExtDNS:  p.KeepExtDNS,
+		CreatedAt:   p.CreatedAt,
+		Resources:   make([]PlannedResource, 0, len(indices)),
+	}
+
+	for i, planned := range p.Resources {
+		if !keep[i] {
+			continue
+		}
+
+		subset.Resources = append(subset.Resources, planned)
+		if i < len(p.resources) {
+			subset.resources = append(subset.resources, p.resources[i])
+		}
+	}
+
+	return subset
+}
+
+// reason returns why a resource was added to the plan, based on the mode
+// the plan was created with and the description of the filters in use.
+func (p *Plan) reason(filters string) string {
+	reason := "selected by nuke everything"
+	if p.OrphansOnly {
+		reason = "orphaned resource"
+	}
+
+	if filters != "" {
+		reason += " with " + filters
+	}
+
+	return reason
+}
+
+// WritePlan encodes the plan as indented JSON into the given writer.
+func WritePlan(w io.Writer, plan *Plan) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(plan); err != nil {
+		return fmt.Errorf("unable to encode plan: %w", err)
+	}
+
+	return nil
+}
+
+// ReadPlan decodes a plan from the given reader. It returns an error if the
+// plan was created by an unsupported version of dropkick or for a different
+// provider.
+func ReadPlan(r io.Reader) (*Plan, error) {
+	var plan Plan
+	if err := json.NewDecoder(r).Decode(&plan); err != nil {
+		return nil, fmt.Errorf("unable to decode plan: %w", err)
+	}
+
+	if plan.Version != planVersion {
+		return nil, fmt.Errorf("unsupported plan version %d: expected version %d", plan.Version, planVersion)
+	}
+
+	if plan.Provider != "civo" {
+		return nil, fmt.Errorf("plan was created for provider %q, not civo", plan.Provider)
+	}
+
+	return &plan, nil
+}
+
+// Plan walks the Civo account in the same way NukeEverything (or
+// NukeOrphanedResources, if orphansOnly is set) would, but instead of
+// deleting the resources it records them into a Plan.
+func (c *Civo) Plan(ctx context.Context, orphansOnly bool) (*Plan, error) {
+	plan := &Plan{
+		Version:     planVersion,
+		Provider:    "civo",
+		Region:      c.region,
+		OrphansOnly: orphansOnly,
+		KeepExtDNS:  orphansOnly && c.keepExtDNS,
+		CreatedAt:   time.Now().UTC(),
+		Resources:   make([]PlannedResource, 0),
+	}
+
+	c.plan = plan
+	defer func() { c.plan = nil }()
+
+	if orphansOnly {
+		if err := c.NukeOrphanedResources(ctx); err != nil {
+			return nil, fmt.Errorf("unable to plan orphaned resources: %w", err)
+		}
+	} else {
+		if err := c.NukeEverything(ctx); err != nil {
+			return nil, fmt.Errorf("unable to plan resources: %w", err)
+		}
+	}
+
+	c.logger.Infof("planned deletion of %d resources", len(plan.Resources))
+	return plan, nil
+}
+
+// ApplyPlan deletes exactly the resources recorded in the plan, in the order
+// they were recorded. Before deleting anything, every planned resource is
+// fetched again: if any of them has disappeared or changed since the plan
+// was created, or for orphans only plans if it's no longer orphaned,
+// nothing is deleted and an error listing every mismatch is returned. The
+// nuke setting is not consulted, since applying a plan is the confirmation
+// itself. With keep going enabled, a failed deletion doesn't stop the
+// remaining ones.
+func (c *Civo) ApplyPlan(ctx context.Context, plan *Plan) error {
+	if plan.Region != c.region {
+		return fmt.Errorf("plan was created for region %q, but the client targets region %q", plan.Region, c.region)
+	}
+
+	c.logger.Infof("verifying %d planned resources", len(plan.Resources))
+
+	var orphans map[string]bool
+	if plan.OrphansOnly {
+		var err error
+		if orphans, err = c.currentOrphans(ctx, plan.KeepExtDNS); err != nil {
+			return fmt.Errorf("unable to check if the planned resources are still orphaned: %w", err)
+		}
+	}
+
+	resources := make([]sdk.APIResource, 0, len(plan.Resources))
+	var errs []error
+
+	for _, planned := range plan.Resources {
+		resource, err := c.verifyPlanned(ctx, planned)
+		if err == nil && plan.OrphansOnly && !orphans[planned.Type+"/"+planned.ID] {
+			err = fmt.Errorf("planned %s %q (ID: %q) is no longer orphaned", planned.Type, planned.Name, planned.ID)
+		}
+
+		if err != nil {
+			c.logger.Errorf("%s", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("refusing to apply plan: %w", errors.Join(errs...))
+	}
+
+	end := c.trackFailures()
+
+	for _, resource := range resources {
+		if err := c.deleteUnlessBlocked(ctx, resource); err != nil {
+			return end(err)
+		}
+	}
+
+	return end(nil)
+}
+
+// verifyPlanned fetches the current state of a planned resource and checks
+// it still matches what was recorded in the plan.
+//
+//nolint:ireturn // the concrete type depends on the planned resource type
+func (c *Civo) verifyPlanned(ctx context.Context, planned PlannedResource) (sdk.APIResource, error) {
+	empty, err := sdk.NewResource(planned.Type, planned.ID)
+	if err != nil {
+		return nil, fmt.Errorf("planned resource %q: %w", planned.ID, err)
+	}
+
+	current, err := c.client.Get(ctx, empty)
+	if err != nil {
+		if errors.Is(err, sdk.ErrNotFound) {
+			return nil, fmt.Errorf("planned %s %q (ID: %q) no longer exists", planned.Type, planned.Name, planned.ID)
+		}
+
+		return nil, fmt.Errorf("unable to fetch planned %s %q (ID: %q): %w", planned.Type, planned.Name, planned.ID, err)
+	}
+
+	if current.GetName() != planned.Name {
+		return nil, fmt.Errorf("planned %s %q (ID: %q) has changed: its name is now %q", planned.Type, planned.Name, planned.ID, current.GetName())
+	}
+
+	if changes := stateChanges(planned.State, resourceState(current)); len(changes) > 0 {
+		return nil, fmt.Errorf("planned %s %q (ID: %q) has changed: %s", planned.Type, planned.Name, planned.ID, strings.Join(changes, ", "))
+	}
+
+	if rule, ok := c.protect.Match(current.GetResourceType(), current.GetID(), current.GetName()); ok {
+		return nil, fmt.Errorf("planned %s %q (ID: %q) is protected by rule %s", planned.Type, planned.Name, planned.ID, rule)
+	}
+
+	return current, nil
+}
+
+// currentOrphans finds the resources orphaned right now, the same way an
+// orphans only plan does, keyed by their type and their key in a plan.
+func (c *Civo) currentOrphans(ctx context.Context, keepExtDNS bool) (map[string]bool, error) {
+	plan := &Plan{Region: c.region, OrphansOnly: true}
+
+	prevPlan, prevReport, prevKeepExtDNS := c.plan, c.report, c.keepExtDNS
+	c.plan, c.report, c.keepExtDNS = plan, nil, keepExtDNS
+	defer func() { c.plan, c.report, c.keepExtDNS = prevPlan, prevReport, prevKeepExtDNS }()
+
+	if err := c.nukeOrphanedResources(ctx); err != nil {
+		return nil, err
+	}
+
+	orphans := make(map[string]bool, len(plan.Resources))
+	for _, planned := range plan.Resources {
+		orphans[planned.Type+"/"+planned.ID] = true
+	}
+
+	return orphans, nil
+}
@@ -0,0 +1,271 @@
+package civo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+	"github.com/konstructio/dropkick/internal/logger"
+)
+
+func TestPlan(t *testing.T) {
+	t.Run("records resources instead of deleting them", func(t *testing.T) {
+		instances := generator[sdk.Instance](3)
+		volumes := generator[sdk.Volume](2)
+
+		mock := &mockClient{
+			fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+				switch resource.(type) {
+				case sdk.Instance:
+					return runEach(instances, fn)
+				case sdk.Volume:
+					return runEach(volumes, fn)
+				}
+				return nil
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				t.Fatalf("expected delete to not be called when planning, got call for %s %q", resource.GetResourceType(), resource.GetID())
+				return nil
+			},
+		}
+
+		c := &Civo{
+			client: mock,
+			logger: logger.None,
+			region: "lon1",
+		}
+
+		plan, err := c.Plan(context.Background(), false)
+		testutils.AssertNoErrorf(t, err, "expected no error when calling Plan, got %v", err)
+		testutils.AssertEqualf(t, len(plan.Resources), 5, "expected 5 planned resources, got %d", len(plan.Resources))
+		testutils.AssertEqual(t, plan.Resources[0].Type, "instance")
+		testutils.AssertEqual(t, plan.Resources[0].Region, "lon1")
+		testutils.AssertEqual(t, plan.Resources[3].Type, "volume")
+
+		if c.plan != nil {
+			t.Fatalf("expected plan to be cleared from the client after planning")
+		}
+	})
+
+	t.Run("round trips through a file", func(t *testing.T) {
+		plan := &Plan{
+			Version:  planVersion,
+			Provider: "civo",
+			Region:   "lon1",
+			Resources: []PlannedResource{
+				{Type: "instance", ID: "1", Name: "instance-1", Region: "lon1", Reason: "orphaned resource", State: map[string]string{"network_id": "n-1"}},
+			},
+		}
+
+		var buf bytes.Buffer
+		testutils.AssertNoError(t, WritePlan(&buf, plan))
+
+		got, err := ReadPlan(&buf)
+		testutils.AssertNoError(t, err)
+		testutils.AssertEqual(t, len(got.Resources), 1)
+
+		if !reflect.DeepEqual(got.Resources[0], plan.Resources[0]) {
+			t.Fatalf("expected %+v, got %+v", plan.Resources[0], got.Resources[0])
+		}
+	})
+
+	t.Run("rejects unsupported plan versions", func(t *testing.T) {
+		_, err := ReadPlan(bytes.NewBufferString(`{"version": 99, "provider": "civo"}`))
+		testutils.AssertErrorf(t, err, "expected error when reading a plan with an unsupported version")
+	})
+}
+
+func TestApplyPlan(t *testing.T) {
+	plan := &Plan{
+		Version:  planVersion,
+		Provider: "civo",
+		Region:   "lon1",
+		Resources: []PlannedResource{
+			{Type: "instance", ID: "1", Name: "instance-1", Region: "lon1"},
+			{Type: "network", ID: "2", Name: "network-2", Region: "lon1"},
+		},
+	}
+
+	t.Run("deletes exactly the planned resources", func(t *testing.T) {
+		var deleted []string
+
+		mock := &mockClient{
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				switch resource.(type) {
+				case sdk.Instance:
+					return sdk.Instance{ID: "1", Name: "instance-1"}, nil
+				case sdk.Network:
+					return sdk.Network{ID: "2", Label: "network-2"}, nil
+				}
+				return nil, sdk.ErrNotFound
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				deleted = append(deleted, resource.GetID())
+				return nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, region: "lon1"}
+
+		err := c.ApplyPlan(context.Background(), plan)
+		testutils.AssertNoErrorf(t, err, "expected no error when applying plan, got %v", err)
+		testutils.AssertEqualf(t, len(deleted), 2, "expected 2 deleted resources, got %d", len(deleted))
+		testutils.AssertEqual(t, deleted[0], "1")
+		testutils.AssertEqual(t, deleted[1], "2")
+	})
+
+	t.Run("refuses when a resource disappeared or changed", func(t *testing.T) {
+		mock := &mockClient{
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				switch resource.(type) {
+				case sdk.Instance:
+					return nil, sdk.ErrNotFound
+				case sdk.Network:
+					return sdk.Network{ID: "2", Label: "renamed"}, nil
+				}
+				return nil, errors.New("unexpected resource")
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				t.Fatalf("expected delete to not be called, got call for %s %q", resource.GetResourceType(), resource.GetID())
+				return nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, region: "lon1"}
+
+		err := c.ApplyPlan(context.Background(), plan)
+		testutils.AssertErrorf(t, err, "expected error when applying a stale plan")
+	})
+
+	t.Run("refuses when a resource was attached to something else", func(t *testing.T) {
+		volumePlan := &Plan{Version: planVersion, Provider: "civo", Region: "lon1"}
+		volumePlan.add(sdk.Volume{ID: "v-1", Name: "data", Status: "available"}, "")
+
+		mock := &mockClient{
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				return sdk.Volume{ID: "v-1", Name: "data", Status: "attached", InstanceID: "i-1"}, nil
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				t.Fatalf("expected delete to not be called, got call for %s %q", resource.GetResourceType(), resource.GetID())
+				return nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, region: "lon1"}
+
+		err := c.ApplyPlan(context.Background(), volumePlan)
+		testutils.AssertErrorf(t, err, "expected error when applying a plan for a volume attached since")
+
+		if !strings.Contains(err.Error(), `its instance_id changed from "" to "i-1"`) || !strings.Contains(err.Error(), `its status changed from "available" to "attached"`) {
+			t.Fatalf("expected the changed fields to be reported, got %v", err)
+		}
+	})
+
+	t.Run("refuses when a resource is no longer orphaned", func(t *testing.T) {
+		orphansPlan := &Plan{Version: planVersion, Provider: "civo", Region: "lon1", OrphansOnly: true}
+		orphansPlan.add(sdk.SSHKey{ID: "k-1", Name: "deploy"}, "")
+		orphansPlan.add(sdk.SSHKey{ID: "k-2", Name: "unused"}, "")
+
+		// an instance was launched with the first key since the plan was
+		// created, which doesn't change anything about the key itself
+		mock := &mockClient{
+			fnGetInstances: func(ctx context.Context) ([]sdk.Instance, error) {
+				return []sdk.Instance{{ID: "i-1", Name: "web", SSHKeyID: "k-1"}}, nil
+			},
+			fnGetSSHKeys: func(ctx context.Context) ([]sdk.SSHKey, error) {
+				return []sdk.SSHKey{{ID: "k-1", Name: "deploy"}, {ID: "k-2", Name: "unused"}}, nil
+			},
+			fnGetVolumes:                func(ctx context.Context) ([]sdk.Volume, error) { return nil, nil },
+			fnGetKubernetesClusters:     func(ctx context.Context) ([]sdk.KubernetesCluster, error) { return nil, nil },
+			fnGetLoadBalancers:          func(ctx context.Context) ([]sdk.LoadBalancer, error) { return nil, nil },
+			fnGetDatabases:              func(ctx context.Context) ([]sdk.Database, error) { return nil, nil },
+			fnGetReservedIPs:            func(ctx context.Context) ([]sdk.ReservedIP, error) { return nil, nil },
+			fnGetSnapshots:              func(ctx context.Context) ([]sdk.Snapshot, error) { return nil, nil },
+			fnGetObjectStores:           func(ctx context.Context) ([]sdk.ObjectStore, error) { return nil, nil },
+			fnGetObjectStoreCredentials: func(ctx context.Context) ([]sdk.ObjectStoreCredential, error) { return nil, nil },
+			fnGetNetworks:               func(ctx context.Context) ([]sdk.Network, error) { return nil, nil },
+			fnGetFirewalls:              func(ctx context.Context) ([]sdk.Firewall, error) { return nil, nil },
+			fnGetDNSDomains:             func(ctx context.Context) ([]sdk.DNSDomain, error) { return nil, nil },
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				if resource.GetID() == "k-1" {
+					return sdk.SSHKey{ID: "k-1", Name: "deploy"}, nil
+				}
+
+				return sdk.SSHKey{ID: "k-2", Name: "unused"}, nil
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				t.Fatalf("expected delete to not be called, got call for %s %q", resource.GetResourceType(), resource.GetID())
+				return nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, region: "lon1"}
+
+		err := c.ApplyPlan(context.Background(), orphansPlan)
+		testutils.AssertErrorf(t, err, "expected error when applying a plan for a key no longer orphaned")
+
+		if !strings.Contains(err.Error(), `ssh key "deploy" (ID: "k-1") is no longer orphaned`) || strings.Contains(err.Error(), "k-2") {
+			t.Fatalf("expected only the key in use to be reported, got %v", err)
+		}
+	})
+
+	t.Run("refuses a plan for a different region", func(t *testing.T) {
+		c := &Civo{client: &mockClient{}, logger: logger.None, region: "fra1"}
+
+		err := c.ApplyPlan(context.Background(), plan)
+		testutils.AssertErrorf(t, err, "expected error when applying a plan for a different region")
+	})
+
+	t.Run("finds planned DNS records through their domain", func(t *testing.T) {
+		recordPlan := &Plan{Version: planVersion, Provider: "civo", Region: "lon1"}
+		recordPlan.add(sdk.DNSRecord{ID: "r-1", DomainID: "d-1", Name: "www"}, "")
+		testutils.AssertEqual(t, recordPlan.Resources[0].ID, "d-1/r-1")
+
+		var deleted sdk.APIResource
+
+		mock := &mockClient{
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				testutils.AssertEqual(t, resource, sdk.APIResource(sdk.DNSRecord{ID: "r-1", DomainID: "d-1"}))
+				return sdk.DNSRecord{ID: "r-1", DomainID: "d-1", Name: "www"}, nil
+			},
+			fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+				deleted = resource
+				return nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, region: "lon1"}
+
+		err := c.ApplyPlan(context.Background(), recordPlan)
+		testutils.AssertNoErrorf(t, err, "expected no error when applying plan, got %v", err)
+		testutils.AssertEqual(t, deleted.GetAPIEndpoint(), "/v2/dns/d-1/records")
+	})
+}
+
+func TestPlanSelection(t *testing.T) {
+	plan := &Plan{Version: planVersion, Provider: "civo", Region: "lon1"}
+	plan.add(sdk.Instance{ID: "i-1", Name: "web", NetworkID: "n-1"}, "")
+	plan.add(sdk.Volume{ID: "v-1", Name: "data"}, "")
+	plan.add(sdk.Network{ID: "n-1", Name: "net"}, "")
+
+	t.Run("finds the resources a planned resource blocks", func(t *testing.T) {
+		testutils.AssertEqualf(t, 1, len(plan.Blocks(0)), "expected the instance to block one resource, got %v", plan.Blocks(0))
+		testutils.AssertEqual(t, 2, plan.Blocks(0)[0])
+		testutils.AssertEqualf(t, 0, len(plan.Blocks(1)), "expected the volume to block nothing, got %v", plan.Blocks(1))
+		testutils.AssertEqualf(t, 0, len(plan.Blocks(5)), "expected an unknown index to block nothing, got %v", plan.Blocks(5))
+	})
+
+	t.Run("keeps only the selected resources", func(t *testing.T) {
+		subset := plan.Subset([]int{2, 0})
+		testutils.AssertEqualf(t, 2, len(subset.Resources), "expected 2 planned resources, got %d", len(subset.Resources))
+		testutils.AssertEqual(t, "i-1", subset.Resources[0].ID)
+		testutils.AssertEqual(t, "n-1", subset.Resources[1].ID)
+		testutils.AssertEqual(t, "lon1", subset.Region)
+		testutils.AssertEqualf(t, 1, len(subset.Blocks(0)), "expected the instance to still block the network, got %v", subset.Blocks(0))
+	})
+}
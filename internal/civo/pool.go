@@ -0,0 +1,66 @@
+package civo
+
+import (
+	"context"
+	"sync"
+)
+
+// runPool calls fn for every item, running up to workers calls at the same
+// time. After the first error, or once the context is cancelled, no new items
+// are handed out; the calls already running are waited for, and then the
+// first error (or the context error) is returned. The context passed to fn
+// is cancelled as soon as any call fails.
+func runPool[T any](ctx context.Context, workers int, items []T, fn func(context.Context, T) error) error {
+	return runShared(ctx, newSlots(workers), items, fn)
+}
+
+// newSlots returns a semaphore letting up to workers calls run at the same
+// time, or a single one if workers is lower than 1.
+func newSlots(workers int) chan struct{} {
+	return make(chan struct{}, max(workers, 1))
+}
+
+// runShared works like runPool, but takes its slots from sem, so the pools
+// sharing it never run more calls altogether than its capacity.
+func runShared[T any](ctx context.Context, sem chan struct{}, items []T, fn func(context.Context, T) error) error {
+	poolCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	for _, item := range items {
+		if poolCtx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-poolCtx.Done():
+		case sem <- struct{}{}:
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				if err := fn(poolCtx, item); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err() //nolint:wrapcheck // the caller knows about the context it provided
+}
@@ -0,0 +1,109 @@
+package civo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+)
+
+func TestRunPool(t *testing.T) {
+	t.Run("processes every item without exceeding the worker limit", func(t *testing.T) {
+		var (
+			running  atomic.Int32
+			maxSeen  atomic.Int32
+			executed atomic.Int32
+		)
+
+		items := make([]int, 50)
+
+		err := runPool(context.Background(), 4, items, func(_ context.Context, _ int) error {
+			current := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				seen := maxSeen.Load()
+				if current <= seen || maxSeen.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			executed.Add(1)
+			return nil
+		})
+
+		testutils.AssertNoError(t, err)
+		testutils.AssertEqual(t, executed.Load(), int32(len(items)))
+		if maxSeen.Load() > 4 {
+			t.Fatalf("expected at most 4 workers running at the same time, got %d", maxSeen.Load())
+		}
+	})
+
+	t.Run("stops after the first error", func(t *testing.T) {
+		errExpected := errors.New("expected error")
+		var executed atomic.Int32
+
+		err := runPool(context.Background(), 1, make([]int, 10), func(_ context.Context, _ int) error {
+			executed.Add(1)
+			return errExpected
+		})
+
+		testutils.AssertErrorEqual(t, errExpected, err)
+		testutils.AssertEqual(t, executed.Load(), int32(1))
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var executed atomic.Int32
+
+		err := runPool(ctx, 1, make([]int, 10), func(_ context.Context, _ int) error {
+			if executed.Add(1) == 3 {
+				cancel()
+			}
+			return nil
+		})
+
+		testutils.AssertErrorEqual(t, context.Canceled, err)
+		testutils.AssertEqual(t, executed.Load(), int32(3))
+	})
+}
+
+func TestRunShared(t *testing.T) {
+	var running, maxSeen atomic.Int32
+
+	work := func(_ context.Context, _ int) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			seen := maxSeen.Load()
+			if current <= seen || maxSeen.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+
+	sem := newSlots(3)
+	errs := make(chan error, 2)
+
+	for range 2 {
+		go func() {
+			errs <- runShared(context.Background(), sem, make([]int, 20), work)
+		}()
+	}
+
+	for range 2 {
+		testutils.AssertNoError(t, <-errs)
+	}
+
+	if maxSeen.Load() > 3 {
+		t.Fatalf("expected at most 3 calls running at the same time across pools, got %d", maxSeen.Load())
+	}
+}
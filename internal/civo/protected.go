@@ -0,0 +1,104 @@
+package civo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+)
+
+// keptResource is a resource kept during a run, along with the protect rule
+// that kept it, either directly or through the resources it's linked to.
+type keptResource struct {
+	resource sdk.APIResource
+	rule     string
+}
+
+// keeper records the resources kept during a run because of protect rules,
+// so the resources linked to them are kept as well: the ones they hold up,
+// which can't be deleted while they exist, and the ones they're made of. A
+// nil keeper records nothing.
+type keeper struct {
+	mu   sync.Mutex
+	kept []keptResource
+}
+
+// add records that a resource is kept because of the given protect rule.
+func (k *keeper) add(resource sdk.APIResource, rule string) {
+	if k == nil {
+		return
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.kept = append(k.kept, keptResource{resource: resource, rule: rule})
+}
+
+// linked returns the kept resource, if any, the given resource is linked
+// to: a kept resource holding up its deletion, or a kept resource the given
+// one is part of.
+func (k *keeper) linked(resource sdk.APIResource) (keptResource, bool) {
+	if k == nil {
+		return keptResource{}, false
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, kept := range k.kept {
+		for _, d := range dependencies {
+			if d.blocker.GetResourceType() == kept.resource.GetResourceType() &&
+				d.blocked.GetResourceType() == resource.GetResourceType() &&
+				d.linked(kept.resource, resource) {
+				return kept, true
+			}
+
+			if d.part &&
+				d.blocker.GetResourceType() == resource.GetResourceType() &&
+				d.blocked.GetResourceType() == kept.resource.GetResourceType() &&
+				d.linked(resource, kept.resource) {
+				return kept, true
+			}
+		}
+	}
+
+	return keptResource{}, false
+}
+
+// trackProtected starts recording the resources kept during a run. Since a
+// resource is deleted before the ones it's part of, like a load balancer
+// before its Kubernetes cluster, the protected resources of those types are
+// looked up beforehand, so their parts are kept too. It returns a function
+// to call when the run ends, which stops recording.
+func (c *Civo) trackProtected(ctx context.Context) (func(), error) {
+	c.kept = &keeper{}
+	done := func() { c.kept = nil }
+
+	if c.protect == nil {
+		return done, nil
+	}
+
+	listed := make(map[string]bool)
+	for _, d := range dependencies {
+		if !d.part || !c.selects(d.blocker) || listed[d.blocked.GetResourceType()] {
+			continue
+		}
+
+		listed[d.blocked.GetResourceType()] = true
+
+		err := c.client.Each(ctx, d.blocked, func(r sdk.APIResource) error {
+			if rule, ok := c.protect.Match(r.GetResourceType(), r.GetID(), r.GetName()); ok {
+				c.kept.add(r, rule)
+			}
+			return nil
+		})
+		if err != nil {
+			done()
+			return nil, fmt.Errorf("unable to list protected resources of type %q: %w", d.blocked.GetResourceType(), err)
+		}
+	}
+
+	return done, nil
+}
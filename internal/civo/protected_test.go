@@ -0,0 +1,77 @@
+package civo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/protect"
+	"github.com/konstructio/dropkick/internal/report"
+)
+
+func TestProtectedLinks(t *testing.T) {
+	rules, err := protect.Parse(strings.NewReader("ids: [\"i1\"]\nname_globs: [\"prod-*\"]"))
+	testutils.AssertNoError(t, err)
+
+	var deleted []string
+
+	mock := &mockClient{
+		fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+			switch resource.(type) {
+			case sdk.LoadBalancer:
+				return runEach([]sdk.LoadBalancer{{ID: "lb1", Name: "ingress", ClusterID: "k1", FirewallID: "f1"}}, fn)
+			case sdk.KubernetesCluster:
+				return runEach([]sdk.KubernetesCluster{{ID: "k1", Name: "prod-cluster"}}, fn)
+			case sdk.Instance:
+				return runEach([]sdk.Instance{{ID: "i1", Name: "bastion", NetworkID: "n1"}}, fn)
+			case sdk.Volume:
+				return runEach([]sdk.Volume{{ID: "v1", Name: "pvc", ClusterID: "k1"}}, fn)
+			case sdk.Firewall:
+				return runEach([]sdk.Firewall{{ID: "f1", Name: "ingress-firewall"}, {ID: "f2", Name: "firewall"}}, fn)
+			case sdk.Network:
+				return runEach([]sdk.Network{{ID: "n1", Label: "bastion-network"}, {ID: "n2", Label: "network"}}, fn)
+			default:
+				return nil
+			}
+		},
+		fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+			deleted = append(deleted, resource.GetID())
+			return nil
+		},
+	}
+
+	recorder := report.NewRecorder()
+	c := &Civo{client: mock, logger: logger.None, nuke: true, protect: rules, report: recorder}
+
+	err = c.NukeEverything(context.Background())
+	testutils.AssertNoErrorf(t, err, "expected no error when calling NukeEverything, got %v", err)
+
+	slices.Sort(deleted)
+	testutils.AssertEqualf(t, 2, len(deleted), "expected only the unrelated resources to be deleted, got %v", deleted)
+	testutils.AssertEqualf(t, "f2", deleted[0], "expected the unrelated firewall to be deleted, got %v", deleted)
+	testutils.AssertEqualf(t, "n2", deleted[1], "expected the unrelated network to be deleted, got %v", deleted)
+
+	reasons := make(map[string]string)
+	for _, r := range recorder.Report().Resources {
+		if r.Action == report.ActionProtected {
+			reasons[r.ID] = r.Reason
+		}
+	}
+
+	expected := map[string]string{
+		"lb1": `kubernetes cluster "prod-cluster", which is kept by rule name glob "prod-*"`,
+		"v1":  `kubernetes cluster "prod-cluster", which is kept by rule name glob "prod-*"`,
+		"f1":  `load balancer "ingress", which is kept by rule name glob "prod-*"`,
+		"n1":  `instance "bastion", which is kept by rule ID "i1"`,
+	}
+
+	for id, want := range expected {
+		if !strings.Contains(reasons[id], want) {
+			t.Fatalf("expected %q to be kept because it is linked to %s, got %q", id, want, reasons[id])
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package civo
+
+import (
+	"fmt"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/report"
+)
+
+// record adds a record about what was done with a resource to the report,
+// if one is being collected.
+func (c *Civo) record(resource sdk.APIResource, selected bool, action report.Action, reason string) {
+	c.report.Add(report.Record{
+		Provider: "civo",
+		Region:   c.region,
+		Type:     resource.GetResourceType(),
+		ID:       resource.GetID(),
+		Name:     resource.GetName(),
+		Selected: selected,
+		Action:   action,
+		Reason:   reason,
+	})
+}
+
+// skip logs that a resource is skipped, and why, and records it.
+func (c *Civo) skip(resource sdk.APIResource, reason string) {
+	c.resourceLogger(resource).Warnf("skipping %s %q: %s", resource.GetResourceType(), resource.GetName(), reason)
+	c.record(resource, false, report.ActionSkipped, reason)
+}
+
+// protected logs that a resource is skipped because it matches a protect
+// rule, and records it.
+func (c *Civo) protected(resource sdk.APIResource, rule string) {
+	reason := "it is protected by rule " + rule
+	c.resourceLogger(resource).Warnf("skipping %s %q: %s", resource.GetResourceType(), resource.GetName(), reason)
+	c.record(resource, false, report.ActionProtected, reason)
+}
+
+// protectedLink logs that a resource is skipped because it's linked to a
+// kept resource, along with the rule that kept it, and records it.
+func (c *Civo) protectedLink(resource sdk.APIResource, kept keptResource) {
+	reason := fmt.Sprintf("it is linked to %s %q, which is kept by rule %s", kept.resource.GetResourceType(), kept.resource.GetName(), kept.rule)
+	c.resourceLogger(resource).Warnf("skipping %s %q: %s", resource.GetResourceType(), resource.GetName(), reason)
+	c.record(resource, false, report.ActionProtected, reason)
+}
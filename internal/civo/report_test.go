@@ -0,0 +1,67 @@
+package civo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/protect"
+	"github.com/konstructio/dropkick/internal/report"
+)
+
+func TestReportRecords(t *testing.T) {
+	rules, err := protect.Parse(strings.NewReader("ids: [\"protected\"]"))
+	testutils.AssertNoError(t, err)
+
+	mock := &mockClient{
+		fnDelete: func(ctx context.Context, resource sdk.APIResource) error {
+			if resource.GetID() == "broken" {
+				return errors.New("boom")
+			}
+
+			return nil
+		},
+	}
+
+	recorder := report.NewRecorder()
+	c := &Civo{client: mock, logger: logger.None, nuke: true, region: "lon1", protect: rules, report: recorder}
+
+	iterFunc := c.deleteIterator(context.Background())
+	testutils.AssertNoError(t, iterFunc(sdk.Instance{ID: "protected", Name: "keep-me"}))
+	testutils.AssertNoError(t, iterFunc(sdk.Instance{ID: "deleted", Name: "delete-me"}))
+	testutils.AssertErrorf(t, iterFunc(sdk.Instance{ID: "broken", Name: "broken"}), "expected the deletion to fail")
+
+	c.nuke = false
+	testutils.AssertNoError(t, iterFunc(sdk.Volume{ID: "refused", Name: "refuse-me"}))
+
+	records := recorder.Report().Resources
+	testutils.AssertEqualf(t, 4, len(records), "expected a record per resource, got %v", records)
+
+	expected := []struct {
+		id       string
+		selected bool
+		action   report.Action
+	}{
+		{id: "protected", selected: false, action: report.ActionProtected},
+		{id: "deleted", selected: true, action: report.ActionDeleted},
+		{id: "broken", selected: true, action: report.ActionFailed},
+		{id: "refused", selected: true, action: report.ActionRefused},
+	}
+
+	for i, want := range expected {
+		got := records[i]
+		testutils.AssertEqualf(t, want.id, got.ID, "expected record %d to be for %q, got %q", i, want.id, got.ID)
+		testutils.AssertEqualf(t, want.selected, got.Selected, "expected record %d selected to be %v", i, want.selected)
+		testutils.AssertEqualf(t, want.action, got.Action, "expected record %d action to be %q, got %q", i, want.action, got.Action)
+		testutils.AssertEqualf(t, "civo", got.Provider, "expected provider civo, got %q", got.Provider)
+		testutils.AssertEqualf(t, "lon1", got.Region, "expected region lon1, got %q", got.Region)
+	}
+
+	if !strings.Contains(records[0].Reason, "protected by rule") || !strings.Contains(records[2].Reason, "boom") {
+		t.Fatalf("expected skip and failure reasons to be recorded, got %q and %q", records[0].Reason, records[2].Reason)
+	}
+}
@@ -41,9 +41,10 @@ type Option func(*Client) error
 // The client can be nil, in which case http.DefaultClient will be used.
 // The endpoint is the base URL for the Civo API.
 // The bearerToken is the token to authenticate with the Civo API.
-func WithJSONClient(client *http.Client, endpoint, bearerToken string) Option {
+// Any additional options are passed down to the JSON client.
+func WithJSONClient(client *http.Client, endpoint, bearerToken string, opts ...json.Option) Option {
 	return func(c *Client) error {
-		c.requester = json.New(client, endpoint, bearerToken)
+		c.requester = json.New(client, endpoint, bearerToken, opts...)
 		return nil
 	}
 }
@@ -85,6 +86,14 @@ func New(opts ...Option) (*Client, error) {
 	return c, nil
 }
 
+// InRegion returns a copy of the client targeting the given region. The copy
+// shares the underlying JSON client, and so its retries and rate limit.
+func (c *Client) InRegion(region string) *Client {
+	clone := *c
+	clone.region = region
+	return &clone
+}
+
 // GetRegion returns the region of the client.
 func (c *Client) GetRegion() string {
 	return c.region
@@ -25,6 +25,19 @@ func Test_New(t *testing.T) {
 		testutils.AssertEqual(t, c.requester.GetEndpoint(), endpoint)
 	})
 
+	t.Run("target another region with the same requester", func(t *testing.T) {
+		c, err := New(
+			WithJSONClient(nil, "https://example.com", "token"),
+			WithRegion("lon1"),
+		)
+		testutils.AssertNoError(t, err)
+
+		other := c.InRegion("fra1")
+		testutils.AssertEqual(t, other.GetRegion(), "fra1")
+		testutils.AssertEqual(t, c.GetRegion(), "lon1")
+		testutils.AssertEqual(t, other.requester, c.requester)
+	})
+
 	t.Run("fail to create a new client", func(t *testing.T) {
 		fakeErr := errors.New("fake error")
 
@@ -30,6 +30,16 @@ func (c *Client) DeleteAll(ctx context.Context, v APIResource, conditionFunc fun
 		return nuke(ctx, c, func(l LoadBalancer) bool { return conditionFunc(l) })
 	case SSHKey:
 		return nuke(ctx, c, func(s SSHKey) bool { return conditionFunc(s) })
+	case ReservedIP:
+		return nuke(ctx, c, func(r ReservedIP) bool { return conditionFunc(r) })
+	case Database:
+		return nuke(ctx, c, func(d Database) bool { return conditionFunc(d) })
+	case DNSDomain:
+		return nuke(ctx, c, func(d DNSDomain) bool { return conditionFunc(d) })
+	case Snapshot:
+		return nuke(ctx, c, func(s Snapshot) bool { return conditionFunc(s) })
+	case DiskImage:
+		return nuke(ctx, c, func(d DiskImage) bool { return d.IsCustom() && conditionFunc(d) })
 	default:
 		return fmt.Errorf("unsupported resource type: %T", r)
 	}
@@ -76,6 +86,18 @@ func (c *Client) Delete(ctx context.Context, resource APIResource) error {
 		return deleteResource(ctx, c, r)
 	case SSHKey:
 		return deleteResource(ctx, c, r)
+	case ReservedIP:
+		return deleteResource(ctx, c, r)
+	case Database:
+		return deleteResource(ctx, c, r)
+	case DNSDomain:
+		return deleteResource(ctx, c, r)
+	case DNSRecord:
+		return deleteResource(ctx, c, r)
+	case Snapshot:
+		return deleteResource(ctx, c, r)
+	case DiskImage:
+		return deleteResource(ctx, c, r)
 	default:
 		return fmt.Errorf("unsupported resource type: %T", r)
 	}
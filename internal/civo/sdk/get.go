@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"strings"
 
 	"github.com/konstructio/dropkick/internal/civo/sdk/json"
 )
@@ -73,6 +74,118 @@ func (c *Client) GetSSHKey(ctx context.Context, sshkeyID string) (*SSHKey, error
 	return sshkey, err
 }
 
+// GetReservedIP gets a reserved IP by ID.
+func (c *Client) GetReservedIP(ctx context.Context, ipID string) (*ReservedIP, error) {
+	ip := &ReservedIP{ID: ipID}
+	err := getByID(ctx, c, ip)
+	return ip, err
+}
+
+// GetDatabase gets a database by ID.
+func (c *Client) GetDatabase(ctx context.Context, databaseID string) (*Database, error) {
+	db := &Database{ID: databaseID}
+	err := getByID(ctx, c, db)
+	return db, err
+}
+
+// GetDNSDomain gets a DNS domain by ID.
+func (c *Client) GetDNSDomain(ctx context.Context, domainID string) (*DNSDomain, error) {
+	domain := &DNSDomain{ID: domainID}
+	err := getByID(ctx, c, domain)
+	return domain, err
+}
+
+// GetDNSRecord gets a record of a DNS domain by ID.
+func (c *Client) GetDNSRecord(ctx context.Context, domainID, recordID string) (*DNSRecord, error) {
+	record := &DNSRecord{ID: recordID, DomainID: domainID}
+	err := getByID(ctx, c, record)
+	return record, err
+}
+
+// GetSnapshot gets an instance snapshot by ID.
+func (c *Client) GetSnapshot(ctx context.Context, snapshotID string) (*Snapshot, error) {
+	snapshot := &Snapshot{ID: snapshotID}
+	err := getByID(ctx, c, snapshot)
+	return snapshot, err
+}
+
+// GetDiskImage gets a disk image by ID.
+func (c *Client) GetDiskImage(ctx context.Context, imageID string) (*DiskImage, error) {
+	image := &DiskImage{ID: imageID}
+	err := getByID(ctx, c, image)
+	return image, err
+}
+
+// Get fetches the current state of the given resource from the Civo API
+// using its ID. It returns ErrNotFound if the resource no longer exists.
+//
+//nolint:ireturn // the concrete type matches the type of the resource provided
+func (c *Client) Get(ctx context.Context, resource APIResource) (APIResource, error) {
+	switch r := resource.(type) {
+	case Instance:
+		return getResource(ctx, c, r)
+	case Firewall:
+		return getResource(ctx, c, r)
+	case Volume:
+		return getResource(ctx, c, r)
+	case KubernetesCluster:
+		return getResource(ctx, c, r)
+	case Network:
+		return getResource(ctx, c, r)
+	case ObjectStore:
+		return getResource(ctx, c, r)
+	case ObjectStoreCredential:
+		return getResource(ctx, c, r)
+	case LoadBalancer:
+		return getResource(ctx, c, r)
+	case SSHKey:
+		return getResource(ctx, c, r)
+	case ReservedIP:
+		return getResource(ctx, c, r)
+	case Database:
+		return getResource(ctx, c, r)
+	case DNSDomain:
+		return getResource(ctx, c, r)
+	case DNSRecord:
+		return getResource(ctx, c, r)
+	case Snapshot:
+		return getResource(ctx, c, r)
+	case DiskImage:
+		return getResource(ctx, c, r)
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %T", r)
+	}
+}
+
+// getResource is a helper function to fetch a resource by ID and return it
+// as an APIResource.
+//
+//nolint:ireturn // the concrete type is always T
+func getResource[T Resource](ctx context.Context, c Civoer, resource T) (APIResource, error) {
+	if err := getByID(ctx, c, &resource); err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// isNotFound checks if the error returned by the Civo API means the
+// resource requested does not exist. Civo either returns a plain 404
+// or a 404 with a "*_not_found" error code in the body. The only exception
+// is "database_account_not_found", which is an authentication failure.
+func isNotFound(err error) bool {
+	if errors.Is(err, &json.HTTPError{Code: http.StatusNotFound}) {
+		return true
+	}
+
+	var civoErr *json.CivoError
+	if errors.As(err, &civoErr) {
+		return strings.HasSuffix(civoErr.Code, "_not_found") && civoErr.Code != "database_account_not_found"
+	}
+
+	return false
+}
+
 // EmptyIDError is returned when the ID field in a resource is empty.
 type EmptyIDError struct {
 	ResourceType string
@@ -104,7 +217,7 @@ func getByID[T Resource](ctx context.Context, c Civoer, resource *T) error {
 
 	fullpath := path.Join(endpoint, id)
 	if err := c.Do(ctx, fullpath, http.MethodGet, resource, params); err != nil {
-		if errors.Is(err, &json.HTTPError{Code: http.StatusNotFound}) {
+		if isNotFound(err) {
 			return ErrNotFound
 		}
 
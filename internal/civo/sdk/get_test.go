@@ -62,6 +62,30 @@ func Test_getByID(t *testing.T) {
 		testutils.AssertEqual(t, instance.Status, expectedInstanceStatus)
 	})
 
+	t.Run("DNS records are fetched through their domain", func(t *testing.T) {
+		c := &testutils.MockCivo{
+			FnDo: func(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
+				testutils.AssertEqual(t, location, "/v2/dns/d-1/records/r-1")
+				output.(*DNSRecord).Name = "www"
+				return nil
+			},
+			FnGetRegion: func() string { return "lon1" },
+		}
+
+		// the record is rebuilt from its key, like when applying a plan
+		resource, err := NewResource("dns record", ResourceKey(DNSRecord{ID: "r-1", DomainID: "d-1"}))
+		testutils.AssertNoError(t, err)
+
+		record := resource.(DNSRecord)
+		testutils.AssertNoError(t, getByID(context.TODO(), c, &record))
+		testutils.AssertEqual(t, record.Name, "www")
+
+		_, err = NewResource("dns record", "r-1")
+		if err == nil {
+			t.Fatal("expected an error for a DNS record key without a domain ID, got nil")
+		}
+	})
+
 	t.Run("instance by ID not found", func(t *testing.T) {
 		ctx := context.TODO()
 
@@ -95,6 +119,46 @@ func Test_getByID(t *testing.T) {
 		}
 	})
 
+	t.Run("instance by ID not found with a Civo error code", func(t *testing.T) {
+		ctx := context.TODO()
+
+		c := &testutils.MockCivo{
+			FnDo: func(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
+				return &json.CivoError{Code: "database_instance_not_found"}
+			},
+
+			FnGetRegion: func() string {
+				return "lon1"
+			},
+		}
+
+		instance := Instance{ID: "123"}
+		err := getByID(ctx, c, &instance)
+		if !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected error to be ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("authentication failure is not a not found error", func(t *testing.T) {
+		ctx := context.TODO()
+
+		c := &testutils.MockCivo{
+			FnDo: func(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
+				return &json.CivoError{Code: "database_account_not_found"}
+			},
+
+			FnGetRegion: func() string {
+				return "lon1"
+			},
+		}
+
+		instance := Instance{ID: "123"}
+		err := getByID(ctx, c, &instance)
+		if errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected error to not be ErrNotFound, got %v", err)
+		}
+	})
+
 	t.Run("empty ID field", func(t *testing.T) {
 		ctx := context.TODO()
 
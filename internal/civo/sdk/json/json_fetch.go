@@ -7,22 +7,81 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 )
 
+// Logger is the interface used to report retried requests.
+type Logger interface {
+	Warnf(format string, v ...interface{})
+}
+
+// noopLogger is a Logger that discards everything.
+type noopLogger struct{}
+
+// Warnf does nothing, by implementing the Logger interface.
+func (noopLogger) Warnf(string, ...interface{}) {}
+
 // Client is a client that can make requests to the Civo API.
 type Client struct {
 	endpoint    string
 	bearerToken string
 	client      *http.Client
+
+	maxRetries int           // how many times a failed idempotent request is retried
+	baseDelay  time.Duration // the delay before the first retry, doubled on every retry
+	maxDelay   time.Duration // the maximum delay between retries
+	logger     Logger        // where retried requests are reported
+	limiter    *rateLimiter  // limits how many requests per second are sent
+}
+
+// Option is a functional option for the Client.
+type Option func(*Client)
+
+// WithRetries configures the client to retry idempotent requests (GET and
+// DELETE) up to maxRetries times when they fail with a 429, a 5xx or a
+// transient network error. The delay between retries starts at baseDelay
+// and doubles on every retry, with jitter, up to maxDelay. If the Civo API
+// sends a Retry-After header, it's honoured instead.
+func WithRetries(maxRetries int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.baseDelay = baseDelay
+		c.maxDelay = maxDelay
+	}
+}
+
+// WithRateLimit limits the client to rps requests per second. A value of
+// zero disables the client-side limit. Regardless of this setting, the
+// client always slows down when the Civo API reports that the rate limit
+// has been reached.
+func WithRateLimit(rps float64) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rps)
+	}
+}
+
+// WithLogger sets the logger used to report retried requests.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
 }
 
 // New creates a new civoJSONClient.
-func New(client *http.Client, endpoint, bearerToken string) *Client {
-	return &Client{
+func New(client *http.Client, endpoint, bearerToken string, opts ...Option) *Client {
+	c := &Client{
 		endpoint:    endpoint,
 		bearerToken: bearerToken,
 		client:      client,
+		logger:      noopLogger{},
+		limiter:     newRateLimiter(0),
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
 // GetClient returns the http client to use for requests as configured
@@ -76,8 +135,9 @@ func (e *CivoError) Is(target error) bool {
 // HTTPError is an error returned when an unexpected HTTP status code is
 // returned by the Civo API.
 type HTTPError struct {
-	Code     int
-	Contents string
+	Code       int
+	Contents   string
+	RetryAfter time.Duration // the delay requested by the Retry-After header, if any
 }
 
 // Error returns the error message for the HTTPError.
@@ -97,8 +157,49 @@ func (e *HTTPError) Is(target error) bool {
 	return ok && err.Code == e.Code
 }
 
-// Do makes a raw HTTP request to the Civo API.
+// Do makes a raw HTTP request to the Civo API. Idempotent requests are
+// retried as configured with WithRetries. If all attempts fail, the error
+// from the last attempt is returned wrapped, so errors.Is still works.
+//
+// A retried DELETE that gets a 404 is considered successful: the previous
+// attempt reached the API and deleted the resource, even though its
+// response was lost.
 func (j *Client) Do(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
+	for attempt := 1; ; attempt++ {
+		err := j.do(ctx, location, method, output, params)
+		if err == nil {
+			return nil
+		}
+
+		if attempt > 1 && method == http.MethodDelete && isNotFound(err) {
+			return nil
+		}
+
+		if !isIdempotent(method) || !isRetryable(ctx, err) {
+			return err
+		}
+
+		if attempt > j.maxRetries {
+			if j.maxRetries == 0 {
+				return err
+			}
+
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+
+		delay := j.retryDelay(attempt, err)
+		j.logger.Warnf("attempt %d of %d for %s %s failed, retrying in %s: %s", attempt, j.maxRetries+1, method, location, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: last error: %w", ctx.Err(), err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// do makes a single HTTP request to the Civo API.
+func (j *Client) do(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
 	u, err := url.Parse(mergeHostPath(j.endpoint, location))
 	if err != nil {
 		return fmt.Errorf("unable to parse requested url: %w", err)
@@ -118,12 +219,20 @@ func (j *Client) Do(ctx context.Context, location, method string, output interfa
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", j.bearerToken))
 
+	if err := j.limiter.wait(ctx); err != nil {
+		return fmt.Errorf("unable to wait for rate limiter: %w", err)
+	}
+
 	res, err := j.GetClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("unable to send request: %w", err)
 	}
 	defer res.Body.Close()
 
+	if paused := j.limiter.observe(res); paused > 0 {
+		j.logger.Warnf("civo API rate limit reached, pausing requests for %s", paused.Round(time.Second))
+	}
+
 	switch res.StatusCode {
 	// Successful cases where we expect the body to have what's supposed
 	// to be returned.
@@ -149,7 +258,7 @@ func (j *Client) Do(ctx context.Context, location, method string, output interfa
 	default:
 		var buf bytes.Buffer
 		buf.ReadFrom(res.Body)
-		return &HTTPError{Code: res.StatusCode, Contents: buf.String()}
+		return &HTTPError{Code: res.StatusCode, Contents: buf.String(), RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"))}
 	}
 
 	if output != nil {
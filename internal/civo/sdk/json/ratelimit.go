@@ -0,0 +1,129 @@
+package json
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// epochThreshold is used to tell apart rate limit reset headers sent as a
+// number of seconds from those sent as a Unix timestamp.
+const epochThreshold = 1_000_000_000
+
+// rateLimiter is a token bucket limiting how many requests per second are
+// sent to the Civo API. On top of the configured rate, it can be paused
+// until a given time, which is used to adapt to the rate limit headers
+// returned by the API. A rate of zero means no client-side limit, but
+// pauses still apply.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64   // tokens added per second
+	burst       float64   // maximum number of tokens in the bucket
+	tokens      float64   // tokens currently available
+	last        time.Time // last time tokens were added
+	pausedUntil time.Time // no requests are allowed before this time
+}
+
+// newRateLimiter creates a rate limiter allowing rps requests per second,
+// with bursts of up to rps requests (and at least one).
+func newRateLimiter(rps float64) *rateLimiter {
+	burst := max(rps, 1)
+
+	return &rateLimiter{
+		rate:   rps,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a request is allowed or the context is cancelled.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	for {
+		delay := r.reserve(time.Now())
+		if delay == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck // the caller knows about the context it provided
+		case <-time.After(delay):
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns zero, or returns how
+// long to wait before trying again.
+func (r *rateLimiter) reserve(now time.Time) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Before(r.pausedUntil) {
+		return r.pausedUntil.Sub(now)
+	}
+
+	if r.rate <= 0 {
+		return 0
+	}
+
+	r.tokens = min(r.burst, r.tokens+now.Sub(r.last).Seconds()*r.rate)
+	r.last = now
+
+	if r.tokens >= 1 {
+		r.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - r.tokens) / r.rate * float64(time.Second))
+}
+
+// pause stops all requests until the given time.
+func (r *rateLimiter) pause(until time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if until.After(r.pausedUntil) {
+		r.pausedUntil = until
+	}
+}
+
+// observe adapts the limiter to the rate limit headers returned by the API.
+// It returns how long requests were paused for, or zero if they weren't.
+// When the API reports no requests are left in the current window, requests
+// are paused until the window resets. A Retry-After header on a 429 response
+// pauses requests for the requested time.
+func (r *rateLimiter) observe(res *http.Response) time.Duration {
+	now := time.Now()
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		if delay := parseRetryAfter(res.Header.Get("Retry-After")); delay > 0 {
+			r.pause(now.Add(delay))
+			return delay
+		}
+	}
+
+	remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining > 0 {
+		return 0
+	}
+
+	reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || reset <= 0 {
+		return 0
+	}
+
+	until := now.Add(time.Duration(reset) * time.Second)
+	if reset > epochThreshold {
+		until = time.Unix(reset, 0)
+	}
+
+	if !until.After(now) {
+		return 0
+	}
+
+	r.pause(until)
+	return until.Sub(now)
+}
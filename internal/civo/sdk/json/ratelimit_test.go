@@ -0,0 +1,103 @@
+package json
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_rateLimiter(t *testing.T) {
+	t.Run("allows a burst then spaces out requests", func(t *testing.T) {
+		limiter := newRateLimiter(2)
+		now := limiter.last
+
+		for i := 0; i < 2; i++ {
+			if delay := limiter.reserve(now); delay != 0 {
+				t.Fatalf("expecting request %d to be allowed, got a delay of %s", i+1, delay)
+			}
+		}
+
+		if delay := limiter.reserve(now); delay != 500*time.Millisecond {
+			t.Fatalf("expecting a delay of 500ms, got %s", delay)
+		}
+
+		if delay := limiter.reserve(now.Add(500 * time.Millisecond)); delay != 0 {
+			t.Fatalf("expecting request to be allowed after waiting, got a delay of %s", delay)
+		}
+	})
+
+	t.Run("no limit when the rate is zero", func(t *testing.T) {
+		limiter := newRateLimiter(0)
+
+		for i := 0; i < 100; i++ {
+			if delay := limiter.reserve(time.Now()); delay != 0 {
+				t.Fatalf("expecting request %d to be allowed, got a delay of %s", i+1, delay)
+			}
+		}
+	})
+
+	t.Run("pauses when no requests are left in the window", func(t *testing.T) {
+		limiter := newRateLimiter(0)
+
+		res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+		res.Header.Set("X-RateLimit-Remaining", "0")
+		res.Header.Set("X-RateLimit-Reset", "10")
+
+		if paused := limiter.observe(res); paused <= 9*time.Second {
+			t.Fatalf("expecting requests to be paused for about 10s, got %s", paused)
+		}
+
+		if delay := limiter.reserve(time.Now()); delay <= 9*time.Second {
+			t.Fatalf("expecting a delay of about 10s, got %s", delay)
+		}
+	})
+
+	t.Run("understands reset headers sent as a timestamp", func(t *testing.T) {
+		limiter := newRateLimiter(0)
+
+		res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+		res.Header.Set("X-RateLimit-Remaining", "0")
+		res.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+
+		if paused := limiter.observe(res); paused <= 58*time.Second || paused > time.Minute {
+			t.Fatalf("expecting requests to be paused for about a minute, got %s", paused)
+		}
+	})
+
+	t.Run("pauses on a 429 with retry-after", func(t *testing.T) {
+		limiter := newRateLimiter(0)
+
+		res := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		res.Header.Set("Retry-After", "3")
+
+		if paused := limiter.observe(res); paused != 3*time.Second {
+			t.Fatalf("expecting requests to be paused for 3s, got %s", paused)
+		}
+	})
+
+	t.Run("does not pause while requests are left", func(t *testing.T) {
+		limiter := newRateLimiter(0)
+
+		res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+		res.Header.Set("X-RateLimit-Remaining", "10")
+		res.Header.Set("X-RateLimit-Reset", "10")
+
+		if paused := limiter.observe(res); paused != 0 {
+			t.Fatalf("expecting no pause, got %s", paused)
+		}
+	})
+
+	t.Run("wait stops when the context is cancelled", func(t *testing.T) {
+		limiter := newRateLimiter(0)
+		limiter.pause(time.Now().Add(time.Hour))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := limiter.wait(ctx); err == nil {
+			t.Fatalf("expecting an error when the context is cancelled")
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// isIdempotent checks if a request with the given method can be safely retried.
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodDelete
+}
+
+// isRetryable checks if an error is caused by rate limiting, a server-side
+// failure or a transient network error: a timeout, a connection reset or
+// refused, or a response cut short. Other network errors, like TLS failures
+// or the API host not resolving, won't go away by retrying.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusTooManyRequests || httpErr.Code >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isNotFound checks if an error means the requested resource does not
+// exist, either as a plain 404 or as a "*_not_found" Civo error code. The
+// "database_account_not_found" code is an authentication failure instead.
+func isNotFound(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusNotFound
+	}
+
+	var civoErr *CivoError
+	if errors.As(err, &civoErr) {
+		return strings.HasSuffix(civoErr.Code, "_not_found") && civoErr.Code != "database_account_not_found"
+	}
+
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt. It honours
+// the Retry-After header if the API sent one, otherwise it uses an
+// exponential backoff with jitter: a random duration between half and all
+// of the backoff for the given attempt.
+func (j *Client) retryDelay(attempt int, err error) time.Duration {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
+		return httpErr.RetryAfter
+	}
+
+	backoff := j.baseDelay << (attempt - 1)
+	if backoff <= 0 || (j.maxDelay > 0 && backoff > j.maxDelay) {
+		backoff = j.maxDelay
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date. It returns zero if the value is
+// empty or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,255 @@
+package json
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// recordingLogger is a Logger that counts how many times it was called.
+type recordingLogger struct {
+	calls atomic.Int32
+}
+
+func (r *recordingLogger) Warnf(string, ...interface{}) { r.calls.Add(1) }
+
+func Test_jsonClient_retries(t *testing.T) {
+	t.Run("retries a get request until it succeeds", func(t *testing.T) {
+		var attempts atomic.Int32
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.Write([]byte(`{"name":"test"}`))
+		}
+
+		srv := createServer(t, http.MethodGet, "/users/me", handler)
+		defer srv.Close()
+
+		logger := &recordingLogger{}
+		client := New(nil, srv.URL, "token", WithRetries(3, time.Millisecond, 10*time.Millisecond), WithLogger(logger))
+
+		var output struct {
+			Name string `json:"name"`
+		}
+
+		if err := client.Do(context.Background(), "/users/me", http.MethodGet, &output, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := attempts.Load(); got != 3 {
+			t.Fatalf("expecting 3 attempts, got %d", got)
+		}
+
+		if got := logger.calls.Load(); got != 2 {
+			t.Fatalf("expecting 2 retries to be logged, got %d", got)
+		}
+
+		if output.Name != "test" {
+			t.Fatalf("expecting value for name to be %q, got %q", "test", output.Name)
+		}
+	})
+
+	t.Run("keeps the http error after giving up", func(t *testing.T) {
+		var attempts atomic.Int32
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+
+		srv := createServer(t, http.MethodDelete, "/instances/1", handler)
+		defer srv.Close()
+
+		client := New(nil, srv.URL, "token", WithRetries(2, time.Millisecond, time.Millisecond))
+
+		err := client.Do(context.Background(), "/instances/1", http.MethodDelete, nil, nil)
+		if !errors.Is(err, &HTTPError{Code: http.StatusTooManyRequests}) {
+			t.Fatalf("expecting error to be a 429 HTTPError, got %v", err)
+		}
+
+		if got := attempts.Load(); got != 3 {
+			t.Fatalf("expecting 3 attempts, got %d", got)
+		}
+	})
+
+	t.Run("does not retry non-idempotent requests", func(t *testing.T) {
+		var attempts atomic.Int32
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		srv := createServer(t, http.MethodPost, "/instances", handler)
+		defer srv.Close()
+
+		client := New(nil, srv.URL, "token", WithRetries(3, time.Millisecond, time.Millisecond))
+
+		err := client.Do(context.Background(), "/instances", http.MethodPost, nil, nil)
+		if !errors.Is(err, &HTTPError{Code: http.StatusInternalServerError}) {
+			t.Fatalf("expecting error to be a 500 HTTPError, got %v", err)
+		}
+
+		if got := attempts.Load(); got != 1 {
+			t.Fatalf("expecting 1 attempt, got %d", got)
+		}
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		var attempts atomic.Int32
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"code":"database_network_inuse_by_instance"}`))
+		}
+
+		srv := createServer(t, http.MethodDelete, "/networks/1", handler)
+		defer srv.Close()
+
+		client := New(nil, srv.URL, "token", WithRetries(3, time.Millisecond, time.Millisecond))
+
+		err := client.Do(context.Background(), "/networks/1", http.MethodDelete, nil, nil)
+		if !errors.Is(err, &CivoError{Code: "database_network_inuse_by_instance"}) {
+			t.Fatalf("expecting error to be a CivoError, got %v", err)
+		}
+
+		if got := attempts.Load(); got != 1 {
+			t.Fatalf("expecting 1 attempt, got %d", got)
+		}
+	})
+
+	t.Run("treats a 404 on a retried delete as success", func(t *testing.T) {
+		var attempts atomic.Int32
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) == 1 {
+				// The first attempt deletes the resource, but its response
+				// never makes it back before the client times out.
+				<-r.Context().Done()
+				return
+			}
+
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"database_instance_not_found"}`))
+		}
+
+		srv := createServer(t, http.MethodDelete, "/instances/1", handler)
+		defer srv.Close()
+
+		client := New(&http.Client{Timeout: 50 * time.Millisecond}, srv.URL, "token", WithRetries(3, time.Millisecond, time.Millisecond))
+
+		if err := client.Do(context.Background(), "/instances/1", http.MethodDelete, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := attempts.Load(); got != 2 {
+			t.Fatalf("expecting 2 attempts, got %d", got)
+		}
+	})
+
+	t.Run("keeps a 404 on the first delete attempt", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"database_instance_not_found"}`))
+		}
+
+		srv := createServer(t, http.MethodDelete, "/instances/1", handler)
+		defer srv.Close()
+
+		client := New(nil, srv.URL, "token", WithRetries(3, time.Millisecond, time.Millisecond))
+
+		err := client.Do(context.Background(), "/instances/1", http.MethodDelete, nil, nil)
+		if !errors.Is(err, &CivoError{Code: "database_instance_not_found"}) {
+			t.Fatalf("expecting error to be a CivoError, got %v", err)
+		}
+	})
+
+	t.Run("honours the retry-after header", func(t *testing.T) {
+		client := New(nil, "", "", WithRetries(3, time.Millisecond, time.Millisecond))
+
+		delay := client.retryDelay(1, fmt.Errorf("wrapped: %w", &HTTPError{Code: http.StatusTooManyRequests, RetryAfter: 7 * time.Second}))
+		if delay != 7*time.Second {
+			t.Fatalf("expecting delay to be 7s, got %s", delay)
+		}
+	})
+}
+
+func Test_isRetryable(t *testing.T) {
+	sendErr := func(err error) error {
+		return fmt.Errorf("unable to send request: %w", &url.Error{Op: "Get", URL: "https://api.civo.com/v2/instances", Err: err})
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "rate limited", err: &HTTPError{Code: http.StatusTooManyRequests}, want: true},
+		{name: "server error", err: &HTTPError{Code: http.StatusBadGateway}, want: true},
+		{name: "client error", err: &HTTPError{Code: http.StatusBadRequest}, want: false},
+		{name: "timeout", err: sendErr(os.ErrDeadlineExceeded), want: true},
+		{name: "connection reset", err: sendErr(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), want: true},
+		{name: "connection refused", err: sendErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), want: true},
+		{name: "response cut short", err: sendErr(io.ErrUnexpectedEOF), want: true},
+		{name: "tls failure", err: sendErr(x509.UnknownAuthorityError{}), want: false},
+		{name: "host not found", err: sendErr(&net.DNSError{Err: "no such host", Name: "api.civo.com", IsNotFound: true}), want: false},
+		{name: "invalid url", err: &url.Error{Op: "parse", URL: "::", Err: errors.New("missing protocol scheme")}, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryable(context.Background(), tc.err); got != tc.want {
+				t.Fatalf("expecting %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_retryDelay(t *testing.T) {
+	client := New(nil, "", "", WithRetries(10, 100*time.Millisecond, time.Second))
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		backoff := min(100*time.Millisecond<<(attempt-1), time.Second)
+		delay := client.retryDelay(attempt, errors.New("some error"))
+
+		if delay < backoff/2 || delay > backoff {
+			t.Fatalf("expecting delay for attempt %d to be between %s and %s, got %s", attempt, backoff/2, backoff, delay)
+		}
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "empty", value: "", want: 0},
+		{name: "seconds", value: "5", want: 5 * time.Second},
+		{name: "invalid", value: "soon", want: 0},
+		{name: "date in the past", value: "Mon, 02 Jan 2006 15:04:05 GMT", want: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parseRetryAfter(tc.value); got != tc.want {
+				t.Fatalf("expecting %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
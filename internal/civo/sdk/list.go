@@ -3,6 +3,7 @@ package sdk
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 )
 
@@ -51,6 +52,66 @@ func (c *Client) GetSSHKeys(ctx context.Context) ([]SSHKey, error) {
 	return getAll[SSHKey](ctx, c)
 }
 
+// GetReservedIPs returns all reserved IPs.
+func (c *Client) GetReservedIPs(ctx context.Context) ([]ReservedIP, error) {
+	return getAll[ReservedIP](ctx, c)
+}
+
+// GetDatabases returns all databases.
+func (c *Client) GetDatabases(ctx context.Context) ([]Database, error) {
+	return getAll[Database](ctx, c)
+}
+
+// GetDNSDomains returns all DNS domains.
+func (c *Client) GetDNSDomains(ctx context.Context) ([]DNSDomain, error) {
+	return getAll[DNSDomain](ctx, c)
+}
+
+// GetDNSRecords returns all records of a DNS domain.
+func (c *Client) GetDNSRecords(ctx context.Context, domainID string) ([]DNSRecord, error) {
+	return getSinglePage[DNSRecord](ctx, c, DNSRecord{DomainID: domainID}.GetAPIEndpoint())
+}
+
+// GetSnapshots returns all instance snapshots.
+func (c *Client) GetSnapshots(ctx context.Context) ([]Snapshot, error) {
+	return getAll[Snapshot](ctx, c)
+}
+
+// GetCustomDiskImages returns the custom disk images uploaded to the
+// account, leaving out the ones provided by Civo.
+func (c *Client) GetCustomDiskImages(ctx context.Context) ([]DiskImage, error) {
+	images, err := getAll[DiskImage](ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	custom := make([]DiskImage, 0, len(images))
+	for _, image := range images {
+		if image.IsCustom() {
+			custom = append(custom, image)
+		}
+	}
+
+	return custom, nil
+}
+
+// GetRegions returns all regions available to the account.
+func (c *Client) GetRegions(ctx context.Context) ([]Region, error) {
+	return getRegions(ctx, c)
+}
+
+// getRegions is a helper function to list the regions available to the
+// account. Regions are not scoped to a region, so no region is sent.
+func getRegions(ctx context.Context, c Civoer) ([]Region, error) {
+	var regions []Region
+
+	if err := c.Do(ctx, "/v2/regions", http.MethodGet, &regions, nil); err != nil {
+		return nil, fmt.Errorf("unable to list regions: %w", err)
+	}
+
+	return regions, nil
+}
+
 // Each iterates over all resources of a given type.
 //
 //nolint:dupl // the code uses generics under the hood and there's no support for generics in methods.
@@ -74,6 +135,24 @@ func (c *Client) Each(ctx context.Context, v APIResource, iterator func(APIResou
 		return each(ctx, c, func(l LoadBalancer) error { return iterator(l) })
 	case SSHKey:
 		return each(ctx, c, func(s SSHKey) error { return iterator(s) })
+	case ReservedIP:
+		return each(ctx, c, func(r ReservedIP) error { return iterator(r) })
+	case Database:
+		return each(ctx, c, func(d Database) error { return iterator(d) })
+	case DNSDomain:
+		return each(ctx, c, func(d DNSDomain) error { return iterator(d) })
+	case Snapshot:
+		return each(ctx, c, func(s Snapshot) error { return iterator(s) })
+	case DiskImage:
+		// Disk images provided by Civo can't be deleted, so only the custom
+		// ones are iterated over.
+		return each(ctx, c, func(d DiskImage) error {
+			if !d.IsCustom() {
+				return nil
+			}
+
+			return iterator(d)
+		})
 	default:
 		return fmt.Errorf("unsupported resource type: %T", r)
 	}
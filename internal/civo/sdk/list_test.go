@@ -2,7 +2,9 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"reflect"
 	"strconv"
 	"testing"
 
@@ -72,7 +74,9 @@ func Test_GetAll(t *testing.T) {
 
 		// Ensure all instances are returned in the correct order
 		for i, instance := range instances {
-			testutils.AssertEqualf(t, instance, responses[i], "expected instance to be %v, got %v", responses[i], instance)
+			if !reflect.DeepEqual(instance, responses[i]) {
+				t.Fatalf("expected instance to be %v, got %v", responses[i], instance)
+			}
 		}
 	})
 
@@ -292,6 +296,65 @@ func Test_GetAll(t *testing.T) {
 		_, err := getAll[Volume](ctx, c)
 		testutils.AssertErrorEqual(t, expectedError, err)
 	})
+
+	t.Run("fetch reserved IPs", func(t *testing.T) {
+		ctx := context.TODO()
+
+		c := &testutils.MockCivo{
+			FnDo: func(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
+				// ensure the appropriate endpoint is being called
+				testutils.AssertEqual(t, location, ReservedIP{}.GetAPIEndpoint())
+
+				// ensure reserved IPs are fetched page by page
+				testutils.AssertEqual(t, params["page"], "1")
+
+				// decode the response as returned by the API
+				return json.Unmarshal([]byte(`{"page":1,"per_page":100,"pages":1,"items":[
+					{"id":"1","name":"test-ip-1","ip":"192.0.2.1","assigned_to":{"id":"10","type":"instance","name":"test-instance"}},
+					{"id":"2","name":"test-ip-2","ip":"192.0.2.2","assigned_to":{}}
+				]}`), output)
+			},
+
+			// mock region for all requests
+			FnGetRegion: func() string { return "lon1" },
+		}
+
+		ips, err := getAll[ReservedIP](ctx, c)
+		testutils.AssertNoError(t, err)
+
+		testutils.AssertEqualf(t, len(ips), 2, "expected 2 reserved IPs, got %d", len(ips))
+		testutils.AssertEqual(t, ips[0].AssignedTo, IPAssignee{ID: "10", Type: "instance", Name: "test-instance"})
+		testutils.AssertEqual(t, ips[1].AssignedTo, IPAssignee{})
+	})
+}
+
+func Test_EachDiskImage(t *testing.T) {
+	client := &Client{
+		region: "lon1",
+		requester: &testutils.MockCivo{
+			FnDo: func(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
+				testutils.AssertEqual(t, location, DiskImage{}.GetAPIEndpoint())
+
+				*output.(*[]DiskImage) = []DiskImage{
+					{ID: "1", Name: "ubuntu-jammy"},
+					{ID: "2", Name: "custom-image", CreatedBy: "account-1"},
+				}
+
+				return nil
+			},
+		},
+	}
+
+	var ids []string
+	err := client.Each(context.TODO(), DiskImage{}, func(r APIResource) error {
+		ids = append(ids, r.GetID())
+		return nil
+	})
+	testutils.AssertNoError(t, err)
+
+	// only the custom disk image can be deleted
+	testutils.AssertEqualf(t, len(ids), 1, "expected 1 disk image, got %v", ids)
+	testutils.AssertEqual(t, ids[0], "2")
 }
 
 // getResultsForPage is a helper function to get results for a specific page.
@@ -330,3 +393,35 @@ func getResultsForPage[T any](t *testing.T, results []T, page, perPage int) (ite
 	itemsPerPage = perPage
 	return
 }
+
+func Test_getRegions(t *testing.T) {
+	t.Run("list regions", func(t *testing.T) {
+		c := &testutils.MockCivo{
+			FnDo: func(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
+				testutils.AssertEqual(t, location, "/v2/regions")
+				testutils.AssertEqual(t, method, "GET")
+
+				*output.(*[]Region) = []Region{{Code: "LON1"}, {Code: "FRA1"}}
+				return nil
+			},
+		}
+
+		regions, err := getRegions(context.TODO(), c)
+		testutils.AssertNoError(t, err)
+		testutils.AssertEqual(t, len(regions), 2)
+		testutils.AssertEqual(t, regions[1].Code, "FRA1")
+	})
+
+	t.Run("error listing regions", func(t *testing.T) {
+		fakeErr := errors.New("fake error")
+
+		c := &testutils.MockCivo{
+			FnDo: func(ctx context.Context, location, method string, output interface{}, params map[string]string) error {
+				return fakeErr
+			},
+		}
+
+		_, err := getRegions(context.TODO(), c)
+		testutils.AssertErrorEqual(t, fakeErr, err)
+	})
+}
@@ -2,6 +2,10 @@ package sdk
 
 import (
 	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
 )
 
 // ErrNotFound is returned when an item is not found.
@@ -10,7 +14,7 @@ var ErrNotFound = errors.New("not found")
 // Resource represents any of the Civo resources returned
 // by the Civo API.
 type Resource interface {
-	Instance | Firewall | Volume | KubernetesCluster | Network | ObjectStore | ObjectStoreCredential | SSHKey | LoadBalancer
+	Instance | Firewall | Volume | KubernetesCluster | Network | ObjectStore | ObjectStoreCredential | SSHKey | LoadBalancer | ReservedIP | Database | DNSDomain | DNSRecord | Snapshot | DiskImage
 	APIResource
 }
 
@@ -22,8 +26,80 @@ type APIResource interface {
 	GetAPIEndpoint() string
 	IsSinglePaged() bool
 	GetResourceType() string
+	GetCreatedAt() time.Time
 }
 
+// Tagged is implemented by the resources that support tags. Tags are plain
+// strings, conventionally written as "key=value".
+type Tagged interface {
+	GetTags() []string
+}
+
+// NewResource returns an empty resource of the given resource type, as
+// returned by GetResourceType, with its ID set to the provided value. It
+// returns an error if the resource type is unknown.
+//
+//nolint:ireturn // the concrete type depends on the resource type provided
+func NewResource(resourceType, id string) (APIResource, error) {
+	switch resourceType {
+	case Instance{}.GetResourceType():
+		return Instance{ID: id}, nil
+	case Firewall{}.GetResourceType():
+		return Firewall{ID: id}, nil
+	case Volume{}.GetResourceType():
+		return Volume{ID: id}, nil
+	case KubernetesCluster{}.GetResourceType():
+		return KubernetesCluster{ID: id}, nil
+	case Network{}.GetResourceType():
+		return Network{ID: id}, nil
+	case ObjectStore{}.GetResourceType():
+		return ObjectStore{ID: id}, nil
+	case ObjectStoreCredential{}.GetResourceType():
+		return ObjectStoreCredential{ID: id}, nil
+	case SSHKey{}.GetResourceType():
+		return SSHKey{ID: id}, nil
+	case LoadBalancer{}.GetResourceType():
+		return LoadBalancer{ID: id}, nil
+	case ReservedIP{}.GetResourceType():
+		return ReservedIP{ID: id}, nil
+	case Database{}.GetResourceType():
+		return Database{ID: id}, nil
+	case DNSDomain{}.GetResourceType():
+		return DNSDomain{ID: id}, nil
+	case DNSRecord{}.GetResourceType():
+		domainID, recordID, ok := strings.Cut(id, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid DNS record key %q: expected the domain ID and record ID separated by a slash", id)
+		}
+
+		return DNSRecord{ID: recordID, DomainID: domainID}, nil
+	case Snapshot{}.GetResourceType():
+		return Snapshot{ID: id}, nil
+	case DiskImage{}.GetResourceType():
+		return DiskImage{ID: id}, nil
+	default:
+		return nil, fmt.Errorf("unknown resource type %q", resourceType)
+	}
+}
+
+// ResourceKey returns the value to pass to NewResource, along with the
+// resource type, to get the resource back. It's the ID of the resource,
+// except for DNS records, which can only be reached through their domain,
+// so their key is the domain ID and the record ID separated by a slash.
+func ResourceKey(resource APIResource) string {
+	if r, ok := resource.(DNSRecord); ok {
+		return r.DomainID + "/" + r.ID
+	}
+
+	return resource.GetID()
+}
+
+// Compile-time assertions for each type implementing the Tagged interface.
+var (
+	_ Tagged = &Instance{}
+	_ Tagged = &KubernetesCluster{}
+)
+
 // Compile-time assertions for each type implementing the APIResource interface.
 // This ensures that the types are correctly implemented.
 var (
@@ -36,57 +112,74 @@ var (
 	_ APIResource = &ObjectStoreCredential{}
 	_ APIResource = &SSHKey{}
 	_ APIResource = &LoadBalancer{}
+	_ APIResource = &ReservedIP{}
+	_ APIResource = &Database{}
+	_ APIResource = &DNSDomain{}
+	_ APIResource = &DNSRecord{}
+	_ APIResource = &Snapshot{}
+	_ APIResource = &DiskImage{}
 )
 
 // Instance is a Civo instance.
 type Instance struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Hostname   string `json:"hostname"`
-	FirewallID string `json:"firewall_id"`
-	NetworkID  string `json:"network_id"`
-	SSHKeyID   string `json:"ssh_key_id,omitempty"` // ssh_key_id is not available within a KubernetesCluster: they currently don't use SSH keys
-	Status     string `json:"status"`
-}
-
-func (i Instance) GetID() string               { return i.ID }            // GetID returns the ID of the instance.
-func (i Instance) GetName() string             { return i.Name }          // GetName returns the name of the instance.
-func (i Instance) GetAPIEndpoint() string      { return "/v2/instances" } // GetAPIEndpoint returns the API endpoint for instances.
-func (i Instance) IsSinglePaged() bool         { return false }           // IsSinglePaged returns whether the resource is single paged.
-func (i Instance) GetResourceType() string     { return "instance" }      // GetResourceType returns the type of the resource.
-func (i Instance) ConsumeOtherResources() bool { return false }           // ConsumeOtherResources returns whether the resource blocks deletion of others.
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Hostname   string    `json:"hostname"`
+	PublicIP   string    `json:"public_ip"`
+	PrivateIP  string    `json:"private_ip"`
+	SourceID   string    `json:"source_id"` // the ID of the disk image the instance was launched from
+	FirewallID string    `json:"firewall_id"`
+	NetworkID  string    `json:"network_id"`
+	SSHKeyID   string    `json:"ssh_key_id,omitempty"` // ssh_key_id is not available within a KubernetesCluster: they currently don't use SSH keys
+	Status     string    `json:"status"`
+	Tags       []string  `json:"tags"`
+	CreatedAt  Timestamp `json:"created_at"`
+}
+
+func (i Instance) GetID() string               { return i.ID }             // GetID returns the ID of the instance.
+func (i Instance) GetName() string             { return i.Name }           // GetName returns the name of the instance.
+func (i Instance) GetAPIEndpoint() string      { return "/v2/instances" }  // GetAPIEndpoint returns the API endpoint for instances.
+func (i Instance) IsSinglePaged() bool         { return false }            // IsSinglePaged returns whether the resource is single paged.
+func (i Instance) GetResourceType() string     { return "instance" }       // GetResourceType returns the type of the resource.
+func (i Instance) GetCreatedAt() time.Time     { return i.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
+func (i Instance) GetTags() []string           { return i.Tags }           // GetTags returns the tags of the instance.
+func (i Instance) ConsumeOtherResources() bool { return false }            // ConsumeOtherResources returns whether the resource blocks deletion of others.
 
 // Firewall is a Civo firewall.
 type Firewall struct {
-	ID                string `json:"id"`
-	Name              string `json:"name"`
-	InstanceCount     int    `json:"instance_count"`
-	ClusterCount      int    `json:"cluster_count"`
-	LoadBalancerCount int    `json:"load_balancer_count"`
-	NetworkID         string `json:"network_id"`
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	InstanceCount     int       `json:"instance_count"`
+	ClusterCount      int       `json:"cluster_count"`
+	LoadBalancerCount int       `json:"load_balancer_count"`
+	NetworkID         string    `json:"network_id"`
+	CreatedAt         Timestamp `json:"created_at"`
 }
 
-func (f Firewall) GetID() string           { return f.ID }            // GetID returns the ID of the firewall.
-func (f Firewall) GetName() string         { return f.Name }          // GetName returns the name of the firewall.
-func (f Firewall) GetAPIEndpoint() string  { return "/v2/firewalls" } // GetAPIEndpoint returns the API endpoint for firewalls.
-func (f Firewall) IsSinglePaged() bool     { return true }            // IsSinglePaged returns whether the resource is single paged.
-func (f Firewall) GetResourceType() string { return "firewall" }      // GetResourceType returns the type of the resource.
+func (f Firewall) GetID() string           { return f.ID }             // GetID returns the ID of the firewall.
+func (f Firewall) GetName() string         { return f.Name }           // GetName returns the name of the firewall.
+func (f Firewall) GetAPIEndpoint() string  { return "/v2/firewalls" }  // GetAPIEndpoint returns the API endpoint for firewalls.
+func (f Firewall) IsSinglePaged() bool     { return true }             // IsSinglePaged returns whether the resource is single paged.
+func (f Firewall) GetResourceType() string { return "firewall" }       // GetResourceType returns the type of the resource.
+func (f Firewall) GetCreatedAt() time.Time { return f.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
 
 // Volume is a Civo volume.
 type Volume struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	InstanceID string `json:"instance_id"`
-	NetworkID  string `json:"network_id"`
-	ClusterID  string `json:"cluster_id,omitempty"` // cluster_id is not available within a KubernetesCluster
-	Status     string `json:"status"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	InstanceID string    `json:"instance_id"`
+	NetworkID  string    `json:"network_id"`
+	ClusterID  string    `json:"cluster_id,omitempty"` // cluster_id is not available within a KubernetesCluster
+	Status     string    `json:"status"`
+	CreatedAt  Timestamp `json:"created_at"`
 }
 
-func (v Volume) GetID() string           { return v.ID }          // GetID returns the ID of the volume.
-func (v Volume) GetName() string         { return v.Name }        // GetName returns the name of the volume.
-func (v Volume) GetAPIEndpoint() string  { return "/v2/volumes" } // GetAPIEndpoint returns the API endpoint for volumes.
-func (v Volume) IsSinglePaged() bool     { return true }          // IsSinglePaged returns whether the resource is single paged.
-func (v Volume) GetResourceType() string { return "volume" }      // GetResourceType returns the type of the resource.
+func (v Volume) GetID() string           { return v.ID }             // GetID returns the ID of the volume.
+func (v Volume) GetName() string         { return v.Name }           // GetName returns the name of the volume.
+func (v Volume) GetAPIEndpoint() string  { return "/v2/volumes" }    // GetAPIEndpoint returns the API endpoint for volumes.
+func (v Volume) IsSinglePaged() bool     { return true }             // IsSinglePaged returns whether the resource is single paged.
+func (v Volume) GetResourceType() string { return "volume" }         // GetResourceType returns the type of the resource.
+func (v Volume) GetCreatedAt() time.Time { return v.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
 
 // KubernetesCluster is a Civo Kubernetes cluster.
 type KubernetesCluster struct {
@@ -98,6 +191,8 @@ type KubernetesCluster struct {
 	NetworkID  string     `json:"network_id"`
 	Volumes    []Volume   `json:"volumes"`
 	Instances  []Instance `json:"instances"`
+	Tags       []string   `json:"tags"`
+	CreatedAt  Timestamp  `json:"created_at"`
 }
 
 func (k KubernetesCluster) GetID() string           { return k.ID }                      // GetID returns the ID of the Kubernetes cluster.
@@ -105,21 +200,25 @@ func (k KubernetesCluster) GetName() string         { return k.Name }
 func (k KubernetesCluster) GetAPIEndpoint() string  { return "/v2/kubernetes/clusters" } // GetAPIEndpoint returns the API endpoint for Kubernetes clusters.
 func (k KubernetesCluster) IsSinglePaged() bool     { return false }                     // IsSinglePaged returns whether the resource is single paged.
 func (k KubernetesCluster) GetResourceType() string { return "kubernetes cluster" }      // GetResourceType returns the type of the resource.
+func (k KubernetesCluster) GetTags() []string       { return k.Tags }                    // GetTags returns the tags of the Kubernetes cluster.
+func (k KubernetesCluster) GetCreatedAt() time.Time { return k.CreatedAt.Time }          // GetCreatedAt returns when the resource was created, or the zero time if unknown.
 
 // Network is a Civo network.
 type Network struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Label   string `json:"label"`
-	Status  string `json:"status"`
-	Default bool   `json:"default"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Label     string    `json:"label"`
+	Status    string    `json:"status"`
+	Default   bool      `json:"default"`
+	CreatedAt Timestamp `json:"created_at"`
 }
 
-func (n Network) GetID() string           { return n.ID }           // GetID returns the ID of the network.
-func (n Network) GetName() string         { return n.Label }        // GetName returns the name of the network.
-func (n Network) GetAPIEndpoint() string  { return "/v2/networks" } // GetAPIEndpoint returns the API endpoint for networks.
-func (n Network) IsSinglePaged() bool     { return true }           // IsSinglePaged returns whether the resource is single paged.
-func (n Network) GetResourceType() string { return "network" }      // GetResourceType returns the type of the resource.
+func (n Network) GetID() string           { return n.ID }             // GetID returns the ID of the network.
+func (n Network) GetName() string         { return n.Label }          // GetName returns the name of the network.
+func (n Network) GetAPIEndpoint() string  { return "/v2/networks" }   // GetAPIEndpoint returns the API endpoint for networks.
+func (n Network) IsSinglePaged() bool     { return true }             // IsSinglePaged returns whether the resource is single paged.
+func (n Network) GetResourceType() string { return "network" }        // GetResourceType returns the type of the resource.
+func (n Network) GetCreatedAt() time.Time { return n.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
 
 // ObjectStore is a Civo object store.
 type ObjectStore struct {
@@ -127,6 +226,7 @@ type ObjectStore struct {
 	Name        string                `json:"name"`
 	Credentials ObjectStoreCredential `json:"owner_info"`
 	Status      string                `json:"status"`
+	CreatedAt   Timestamp             `json:"created_at"`
 }
 
 func (o ObjectStore) GetID() string           { return o.ID }               // GetID returns the ID of the object store.
@@ -134,13 +234,15 @@ func (o ObjectStore) GetName() string         { return o.Name }             // G
 func (o ObjectStore) GetAPIEndpoint() string  { return "/v2/objectstores" } // GetAPIEndpoint returns the API endpoint for object stores.
 func (o ObjectStore) IsSinglePaged() bool     { return false }              // IsSinglePaged returns whether the resource is single paged.
 func (o ObjectStore) GetResourceType() string { return "object store" }     // GetResourceType returns the type of the resource.
+func (o ObjectStore) GetCreatedAt() time.Time { return o.CreatedAt.Time }   // GetCreatedAt returns when the resource was created, or the zero time if unknown.
 
 // ObjectStoreCredential is a Civo object store credential.
 type ObjectStoreCredential struct {
-	ID           string `json:"id"`
-	CredentialID string `json:"credential_id"` // only used when pulled via objectstore
-	Name         string `json:"name"`
-	Status       string `json:"status"`
+	ID           string    `json:"id"`
+	CredentialID string    `json:"credential_id"` // only used when pulled via objectstore
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+	CreatedAt    Timestamp `json:"created_at"`
 }
 
 func (o ObjectStoreCredential) GetID() string           { return o.ID }                          // GetID returns the ID of the object store credential.
@@ -148,26 +250,31 @@ func (o ObjectStoreCredential) GetName() string         { return o.Name }
 func (o ObjectStoreCredential) GetAPIEndpoint() string  { return "/v2/objectstore/credentials" } // GetAPIEndpoint returns the API endpoint for object store credentials.
 func (o ObjectStoreCredential) IsSinglePaged() bool     { return false }                         // IsSinglePaged returns whether the resource is single paged.
 func (o ObjectStoreCredential) GetResourceType() string { return "object store credential" }     // GetResourceType returns the type of the resource.
+func (o ObjectStoreCredential) GetCreatedAt() time.Time { return o.CreatedAt.Time }              // GetCreatedAt returns when the resource was created, or the zero time if unknown.
 
 // SSHKey is a Civo SSH key.
 type SSHKey struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Fingerprint string `json:"fingerprint"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Fingerprint string    `json:"fingerprint"`
+	CreatedAt   Timestamp `json:"created_at"`
 }
 
-func (s SSHKey) GetID() string           { return s.ID }          // GetID returns the ID of the SSH key.
-func (s SSHKey) GetName() string         { return s.Name }        // GetName returns the name of the SSH key.
-func (s SSHKey) GetAPIEndpoint() string  { return "/v2/sshkeys" } // GetAPIEndpoint returns the API endpoint for SSH keys.
-func (s SSHKey) IsSinglePaged() bool     { return true }          // IsSinglePaged returns whether the resource is single paged.
-func (s SSHKey) GetResourceType() string { return "ssh key" }     // GetResourceType returns the type of the resource.
+func (s SSHKey) GetID() string           { return s.ID }             // GetID returns the ID of the SSH key.
+func (s SSHKey) GetName() string         { return s.Name }           // GetName returns the name of the SSH key.
+func (s SSHKey) GetAPIEndpoint() string  { return "/v2/sshkeys" }    // GetAPIEndpoint returns the API endpoint for SSH keys.
+func (s SSHKey) IsSinglePaged() bool     { return true }             // IsSinglePaged returns whether the resource is single paged.
+func (s SSHKey) GetResourceType() string { return "ssh key" }        // GetResourceType returns the type of the resource.
+func (s SSHKey) GetCreatedAt() time.Time { return s.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
 
 // LoadBalancer is a Civo load balancer.
 type LoadBalancer struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	FirewallID string `json:"firewall_id"`
-	ClusterID  string `json:"cluster_id"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	PublicIP   string    `json:"public_ip"`
+	FirewallID string    `json:"firewall_id"`
+	ClusterID  string    `json:"cluster_id"`
+	CreatedAt  Timestamp `json:"created_at"`
 }
 
 func (l LoadBalancer) GetID() string           { return l.ID }                // GetID returns the ID of the load balancer.
@@ -175,3 +282,126 @@ func (l LoadBalancer) GetName() string         { return l.Name }              //
 func (l LoadBalancer) GetAPIEndpoint() string  { return "/v2/loadbalancers" } // GetAPIEndpoint returns the API endpoint for load balancers.
 func (l LoadBalancer) IsSinglePaged() bool     { return true }                // IsSinglePaged returns whether the resource is single paged.
 func (l LoadBalancer) GetResourceType() string { return "load balancer" }     // GetResourceType returns the type of the resource.
+func (l LoadBalancer) GetCreatedAt() time.Time { return l.CreatedAt.Time }    // GetCreatedAt returns when the resource was created, or the zero time if unknown.
+
+// ReservedIP is a Civo reserved IP. It's billed while it exists, whether
+// it's assigned to an instance or load balancer or not.
+type ReservedIP struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	IP         string     `json:"ip"`
+	AssignedTo IPAssignee `json:"assigned_to"`
+	CreatedAt  Timestamp  `json:"created_at"`
+}
+
+func (r ReservedIP) GetID() string           { return r.ID }             // GetID returns the ID of the reserved IP.
+func (r ReservedIP) GetName() string         { return r.Name }           // GetName returns the name of the reserved IP.
+func (r ReservedIP) GetAPIEndpoint() string  { return "/v2/ips" }        // GetAPIEndpoint returns the API endpoint for reserved IPs.
+func (r ReservedIP) IsSinglePaged() bool     { return false }            // IsSinglePaged returns whether the resource is single paged.
+func (r ReservedIP) GetResourceType() string { return "reserved ip" }    // GetResourceType returns the type of the resource.
+func (r ReservedIP) GetCreatedAt() time.Time { return r.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
+
+// IPAssignee is the resource a reserved IP is assigned to. All fields are
+// empty when the IP isn't assigned.
+type IPAssignee struct {
+	ID   string `json:"id"`
+	Type string `json:"type"` // "instance" or "loadbalancer"
+	Name string `json:"name"`
+}
+
+// Database is a Civo managed database.
+type Database struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	FirewallID string    `json:"firewall_id"`
+	NetworkID  string    `json:"network_id"`
+	Status     string    `json:"status"`
+	CreatedAt  Timestamp `json:"created_at"`
+}
+
+func (d Database) GetID() string           { return d.ID }             // GetID returns the ID of the database.
+func (d Database) GetName() string         { return d.Name }           // GetName returns the name of the database.
+func (d Database) GetAPIEndpoint() string  { return "/v2/databases" }  // GetAPIEndpoint returns the API endpoint for databases.
+func (d Database) IsSinglePaged() bool     { return false }            // IsSinglePaged returns whether the resource is single paged.
+func (d Database) GetResourceType() string { return "database" }       // GetResourceType returns the type of the resource.
+func (d Database) GetCreatedAt() time.Time { return d.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
+
+// DNSDomain is a domain hosted in Civo DNS. Deleting a domain also deletes
+// its records.
+type DNSDomain struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt Timestamp `json:"created_at"`
+}
+
+func (d DNSDomain) GetID() string           { return d.ID }             // GetID returns the ID of the DNS domain.
+func (d DNSDomain) GetName() string         { return d.Name }           // GetName returns the name of the DNS domain.
+func (d DNSDomain) GetAPIEndpoint() string  { return "/v2/dns" }        // GetAPIEndpoint returns the API endpoint for DNS domains.
+func (d DNSDomain) IsSinglePaged() bool     { return true }             // IsSinglePaged returns whether the resource is single paged.
+func (d DNSDomain) GetResourceType() string { return "dns domain" }     // GetResourceType returns the type of the resource.
+func (d DNSDomain) GetCreatedAt() time.Time { return d.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
+
+// DNSRecord is a record of a domain hosted in Civo DNS. Records are nested
+// under their domain in the API, so the API endpoint of a record depends on
+// its domain ID, and records can't be listed with Each.
+type DNSRecord struct {
+	ID        string    `json:"id"`
+	DomainID  string    `json:"domain_id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"` // like "A", "CNAME", "MX" or "TXT"
+	Value     string    `json:"value"`
+	CreatedAt Timestamp `json:"created_at"`
+}
+
+func (r DNSRecord) GetID() string           { return r.ID }                                        // GetID returns the ID of the DNS record.
+func (r DNSRecord) GetName() string         { return r.Name }                                      // GetName returns the name of the DNS record.
+func (r DNSRecord) GetAPIEndpoint() string  { return path.Join("/v2/dns", r.DomainID, "records") } // GetAPIEndpoint returns the API endpoint for the records of the domain of the DNS record.
+func (r DNSRecord) IsSinglePaged() bool     { return true }                                        // IsSinglePaged returns whether the resource is single paged.
+func (r DNSRecord) GetResourceType() string { return "dns record" }                                // GetResourceType returns the type of the resource.
+func (r DNSRecord) GetCreatedAt() time.Time { return r.CreatedAt.Time }                            // GetCreatedAt returns when the resource was created, or the zero time if unknown.
+
+// Snapshot is a snapshot of a Civo instance.
+type Snapshot struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	InstanceID string    `json:"instance_id"`
+	State      string    `json:"state"`
+	CreatedAt  Timestamp `json:"created_at"`
+}
+
+func (s Snapshot) GetID() string           { return s.ID }             // GetID returns the ID of the snapshot.
+func (s Snapshot) GetName() string         { return s.Name }           // GetName returns the name of the snapshot.
+func (s Snapshot) GetAPIEndpoint() string  { return "/v2/snapshots" }  // GetAPIEndpoint returns the API endpoint for snapshots.
+func (s Snapshot) IsSinglePaged() bool     { return true }             // IsSinglePaged returns whether the resource is single paged.
+func (s Snapshot) GetResourceType() string { return "snapshot" }       // GetResourceType returns the type of the resource.
+func (s Snapshot) GetCreatedAt() time.Time { return s.CreatedAt.Time } // GetCreatedAt returns when the resource was created, or the zero time if unknown.
+
+// DiskImage is a Civo disk image. The API lists the images provided by
+// Civo along with the custom images uploaded to the account, which are the
+// only ones with a creator.
+type DiskImage struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Label        string    `json:"label"`
+	Distribution string    `json:"distribution"`
+	State        string    `json:"state"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    Timestamp `json:"created_at"`
+}
+
+func (d DiskImage) GetID() string           { return d.ID }              // GetID returns the ID of the disk image.
+func (d DiskImage) GetName() string         { return d.Name }            // GetName returns the name of the disk image.
+func (d DiskImage) GetAPIEndpoint() string  { return "/v2/disk_images" } // GetAPIEndpoint returns the API endpoint for disk images.
+func (d DiskImage) IsSinglePaged() bool     { return true }              // IsSinglePaged returns whether the resource is single paged.
+func (d DiskImage) GetResourceType() string { return "disk image" }      // GetResourceType returns the type of the resource.
+func (d DiskImage) GetCreatedAt() time.Time { return d.CreatedAt.Time }  // GetCreatedAt returns when the resource was created, or the zero time if unknown.
+func (d DiskImage) IsCustom() bool          { return d.CreatedBy != "" } // IsCustom returns whether the disk image was uploaded to the account.
+
+// Region is a Civo region. It's not a resource that can be deleted, so it
+// doesn't implement the APIResource interface.
+type Region struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Country       string `json:"country"`
+	OutOfCapacity bool   `json:"out_of_capacity"`
+}
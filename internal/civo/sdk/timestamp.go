@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// timestampLayouts are the layouts tried, in order, when decoding a
+// Timestamp. The Civo API isn't consistent in how it formats dates across
+// resource types.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05",
+}
+
+// Timestamp is a point in time returned by the Civo API. Decoding it is
+// tolerant: null, empty or unparseable values decode into the zero time
+// instead of failing the whole response, since the timestamp is only used
+// to filter resources.
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	t.Time = time.Time{}
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil //nolint:nilerr // non-string timestamps are treated as missing
+	}
+
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return nil
+}
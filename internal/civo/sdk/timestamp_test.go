@@ -0,0 +1,45 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+)
+
+func TestTimestamp(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{name: "rfc3339", input: `"2024-08-17T13:21:08Z"`, want: time.Date(2024, 8, 17, 13, 21, 8, 0, time.UTC)},
+		{name: "rfc3339 with offset", input: `"2024-08-17T14:21:08+01:00"`, want: time.Date(2024, 8, 17, 13, 21, 8, 0, time.UTC)},
+		{name: "rfc3339 with fraction", input: `"2024-08-17T13:21:08.123Z"`, want: time.Date(2024, 8, 17, 13, 21, 8, 123000000, time.UTC)},
+		{name: "space separated", input: `"2024-08-17 13:21:08"`, want: time.Date(2024, 8, 17, 13, 21, 8, 0, time.UTC)},
+		{name: "null", input: `null`},
+		{name: "empty", input: `""`},
+		{name: "garbage", input: `"yesterday"`},
+		{name: "not a string", input: `12`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ts Timestamp
+			err := json.Unmarshal([]byte(tc.input), &ts)
+			testutils.AssertNoErrorf(t, err, "expected no error decoding %s, got %v", tc.input, err)
+
+			if !ts.Equal(tc.want) {
+				t.Fatalf("expected %s to decode as %v, got %v", tc.input, tc.want, ts.Time)
+			}
+		})
+	}
+
+	t.Run("decoded as part of a resource", func(t *testing.T) {
+		var instance Instance
+		err := json.Unmarshal([]byte(`{"id":"1","created_at":"2024-08-17T13:21:08Z"}`), &instance)
+		testutils.AssertNoError(t, err)
+		testutils.AssertEqual(t, time.Date(2024, 8, 17, 13, 21, 8, 0, time.UTC), instance.GetCreatedAt().UTC())
+	})
+}
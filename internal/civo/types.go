@@ -0,0 +1,134 @@
+package civo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/protect"
+)
+
+// selectTypes returns the resource types selected by the only and skip
+// lists, in the order they're declared in resourceTypes. Types can be
+// written in any case, with or without spaces, and singular or plural, so
+// "sshkeys" and "SSH key" both select "ssh key". An empty only list selects
+// every type. It returns an error if a type is unknown or if no type is left.
+func selectTypes(only, skip []string) ([]sdk.APIResource, error) {
+	known := make(map[string]bool, len(resourceTypes))
+	for _, t := range resourceTypes {
+		known[protect.NormalizeType(t.GetResourceType())] = true
+	}
+
+	normalize := func(flag string, types []string) ([]string, error) {
+		normalized := make([]string, 0, len(types))
+
+		for _, t := range types {
+			n := protect.NormalizeType(t)
+			if !known[n] {
+				return nil, fmt.Errorf("unknown resource type %q in %s: valid types are %s", t, flag, typeNames(resourceTypes))
+			}
+
+			normalized = append(normalized, n)
+		}
+
+		return normalized, nil
+	}
+
+	onlyTypes, err := normalize("--only", only)
+	if err != nil {
+		return nil, err
+	}
+
+	skipTypes, err := normalize("--skip", skip)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]sdk.APIResource, 0, len(resourceTypes))
+	for _, t := range resourceTypes {
+		n := protect.NormalizeType(t.GetResourceType())
+
+		if len(onlyTypes) > 0 && !slices.Contains(onlyTypes, n) {
+			continue
+		}
+
+		if slices.Contains(skipTypes, n) {
+			continue
+		}
+
+		selected = append(selected, t)
+	}
+
+	if len(selected) == 0 {
+		return nil, errors.New("no resource types left to process")
+	}
+
+	return selected, nil
+}
+
+// typeNames returns the names of the given resource types, quoted and
+// separated by commas.
+func typeNames(types []sdk.APIResource) string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, fmt.Sprintf("%q", t.GetResourceType()))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// globalTypes are the resource types that belong to the whole account
+// rather than to a region, so listing them in any region returns all of them.
+var globalTypes = []sdk.APIResource{
+	sdk.DNSDomain{},
+}
+
+// isGlobal checks if the type of the given resource belongs to the whole
+// account rather than to a region.
+func isGlobal(resource sdk.APIResource) bool {
+	return slices.ContainsFunc(globalTypes, func(t sdk.APIResource) bool {
+		return t.GetResourceType() == resource.GetResourceType()
+	})
+}
+
+// selects checks if the type of the given resource is selected for processing.
+// Global resource types are never selected if they're left to another region.
+func (c *Civo) selects(resource sdk.APIResource) bool {
+	if c.skipGlobal && isGlobal(resource) {
+		return false
+	}
+
+	if c.types == nil {
+		return true
+	}
+
+	return slices.ContainsFunc(c.types, func(t sdk.APIResource) bool {
+		return t.GetResourceType() == resource.GetResourceType()
+	})
+}
+
+// warnSkippedBlockers logs a warning for every resource type that is not
+// selected but can block the deletion of a selected one, since resources
+// of the selected type might then fail to delete.
+func (c *Civo) warnSkippedBlockers() {
+	warned := make(map[[2]string]bool)
+
+	for _, e := range dependencies {
+		if c.selects(e.blocker) || !c.selects(e.blocked) {
+			continue
+		}
+
+		key := [2]string{e.blocker.GetResourceType(), e.blocked.GetResourceType()}
+		if warned[key] {
+			continue
+		}
+
+		warned[key] = true
+		c.logger.Warnf(
+			"skipping resources of type %q, which can block the deletion of selected resources of type %q",
+			e.blocker.GetResourceType(), e.blocked.GetResourceType(),
+		)
+	}
+}
@@ -0,0 +1,94 @@
+package civo
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+	"github.com/konstructio/dropkick/internal/logger"
+)
+
+func TestSelectTypes(t *testing.T) {
+	t.Run("only accepts plural and spaced types", func(t *testing.T) {
+		types, err := selectTypes([]string{"volumes", "sshkeys", "Object Store"}, nil)
+		testutils.AssertNoErrorf(t, err, "expected no error selecting types, got %v", err)
+
+		got := typeNames(types)
+		testutils.AssertEqualf(t, `"volume", "ssh key", "object store"`, got, "unexpected selected types: %s", got)
+	})
+
+	t.Run("skip removes types", func(t *testing.T) {
+		types, err := selectTypes(nil, []string{"objectstores", "object-store-credentials"})
+		testutils.AssertNoErrorf(t, err, "expected no error selecting types, got %v", err)
+		testutils.AssertEqualf(t, len(resourceTypes)-2, len(types), "expected %d types, got %s", len(resourceTypes)-2, typeNames(types))
+	})
+
+	t.Run("unknown types are rejected", func(t *testing.T) {
+		_, err := selectTypes([]string{"buckets"}, nil)
+		testutils.AssertErrorf(t, err, "expected error for an unknown type")
+	})
+
+	t.Run("no types left is rejected", func(t *testing.T) {
+		_, err := selectTypes([]string{"volumes"}, []string{"volume"})
+		testutils.AssertErrorf(t, err, "expected error when every type is skipped")
+	})
+}
+
+func TestNukeEverythingSelectedTypes(t *testing.T) {
+	var listed []string
+
+	mock := &mockClient{
+		fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+			listed = append(listed, resource.GetResourceType())
+			return nil
+		},
+	}
+
+	types, err := selectTypes([]string{"networks", "instances"}, nil)
+	testutils.AssertNoError(t, err)
+
+	var buf bytes.Buffer
+	c := &Civo{client: mock, logger: logger.New(&buf), types: types}
+
+	err = c.NukeEverything(context.Background())
+	testutils.AssertNoErrorf(t, err, "expected no error when calling NukeEverything, got %v", err)
+
+	testutils.AssertEqualf(t, "instance,network", strings.Join(listed, ","), "expected only selected types to be listed in order, got %v", listed)
+
+	if !strings.Contains(buf.String(), `skipping resources of type "firewall", which can block the deletion of selected resources of type "network"`) {
+		t.Fatalf("expected a warning about skipped firewalls, got:\n%s", buf.String())
+	}
+}
+
+func TestNukeEverythingGlobalTypes(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		skipGlobal bool
+		want       bool
+	}{
+		{name: "global types are processed by default", want: true},
+		{name: "global types are left to another region", skipGlobal: true, want: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var listedDomains bool
+
+			mock := &mockClient{
+				fnEach: func(ctx context.Context, resource sdk.APIResource, fn func(sdk.APIResource) error) error {
+					if _, ok := resource.(sdk.DNSDomain); ok {
+						listedDomains = true
+					}
+					return nil
+				},
+			}
+
+			c := &Civo{client: mock, logger: logger.None, skipGlobal: tc.skipGlobal}
+
+			err := c.NukeEverything(context.Background())
+			testutils.AssertNoErrorf(t, err, "expected no error when calling NukeEverything, got %v", err)
+			testutils.AssertEqualf(t, tc.want, listedDomains, "expected DNS domains listed to be %v", tc.want)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package civo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+)
+
+// maxWaitInterval is the longest time to wait between two checks when
+// polling for a resource to be deleted.
+const maxWaitInterval = 30 * time.Second
+
+// DeletionTimeoutError is returned when a deleted resource is still present
+// in the Civo API after the configured wait timeout.
+type DeletionTimeoutError struct {
+	ResourceType string
+	ID           string
+	Name         string
+	Timeout      time.Duration
+}
+
+// Error returns the error message, by implementing the error interface.
+func (e *DeletionTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for %s %q (ID: %q) to be deleted", e.Timeout, e.ResourceType, e.Name, e.ID)
+}
+
+// blocksOthers checks if other resource types can only be deleted once
+// resources of the same type as the given one are gone.
+func blocksOthers(resource sdk.APIResource) bool {
+	for _, d := range dependencies {
+		if d.blocker.GetResourceType() == resource.GetResourceType() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitForDeletion polls the Civo API until the given resource, which has
+// already been deleted, is no longer found. Civo deletes asynchronously, so
+// without waiting, the resources that depended on this one might still be
+// in use when we try to delete them. Resources that don't block any other
+// resource type aren't waited for. The time between checks doubles after
+// every check, up to maxWaitInterval. It returns a DeletionTimeoutError if
+// the resource is still present after the wait timeout.
+func (c *Civo) waitForDeletion(ctx context.Context, resource sdk.APIResource) error {
+	if c.waitTimeout <= 0 || !blocksOthers(resource) {
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, c.waitTimeout)
+	defer cancel()
+
+	timeoutErr := &DeletionTimeoutError{
+		ResourceType: resource.GetResourceType(),
+		ID:           resource.GetID(),
+		Name:         resource.GetName(),
+		Timeout:      c.waitTimeout,
+	}
+
+	interval := c.waitInterval
+	for {
+		_, err := c.client.Get(waitCtx, resource)
+		if errors.Is(err, sdk.ErrNotFound) {
+			c.resourceLogger(resource).Infof("%s %q is gone", resource.GetResourceType(), resource.GetName())
+			return nil
+		}
+
+		if err != nil && waitCtx.Err() == nil {
+			return fmt.Errorf("unable to check if %s %q was deleted: %w", resource.GetResourceType(), resource.GetName(), err)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err //nolint:wrapcheck // the caller knows about the context it provided
+		}
+
+		if waitCtx.Err() != nil {
+			return timeoutErr
+		}
+
+		c.resourceLogger(resource).Infof("waiting %s for %s %q to be deleted", interval, resource.GetResourceType(), resource.GetName())
+
+		select {
+		case <-waitCtx.Done():
+			if err := ctx.Err(); err != nil {
+				return err //nolint:wrapcheck // the caller knows about the context it provided
+			}
+
+			return timeoutErr
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, maxWaitInterval)
+	}
+}
@@ -0,0 +1,127 @@
+package civo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/civo/sdk"
+	"github.com/konstructio/dropkick/internal/civo/sdk/testutils"
+	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/outputwriter"
+	"github.com/konstructio/dropkick/internal/report"
+)
+
+func TestWaitForDeletion(t *testing.T) {
+	t.Run("polls until the resource is gone", func(t *testing.T) {
+		calls := 0
+
+		mock := &mockClient{
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				calls++
+				if calls < 3 {
+					return resource, nil
+				}
+				return nil, sdk.ErrNotFound
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, waitTimeout: time.Second, waitInterval: time.Millisecond}
+
+		err := c.waitForDeletion(context.Background(), sdk.KubernetesCluster{ID: "1", Name: "cluster-1"})
+		testutils.AssertNoErrorf(t, err, "expected no error when waiting for deletion, got %v", err)
+		testutils.AssertEqual(t, calls, 3)
+	})
+
+	t.Run("reports a timeout per resource", func(t *testing.T) {
+		mock := &mockClient{
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				return resource, nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, waitTimeout: 20 * time.Millisecond, waitInterval: time.Millisecond}
+
+		err := c.waitForDeletion(context.Background(), sdk.Firewall{ID: "1", Name: "firewall-1"})
+
+		var timeoutErr *DeletionTimeoutError
+		if !errors.As(err, &timeoutErr) {
+			t.Fatalf("expected a DeletionTimeoutError, got %v", err)
+		}
+
+		testutils.AssertEqual(t, timeoutErr.ID, "1")
+		testutils.AssertEqual(t, timeoutErr.ResourceType, "firewall")
+	})
+
+	t.Run("returns errors other than not found", func(t *testing.T) {
+		errExpected := errors.New("expected error")
+
+		mock := &mockClient{
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				return nil, errExpected
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, waitTimeout: time.Second, waitInterval: time.Millisecond}
+
+		err := c.waitForDeletion(context.Background(), sdk.Instance{ID: "1", Name: "instance-1"})
+		testutils.AssertErrorEqual(t, errExpected, err)
+	})
+
+	t.Run("resources nothing depends on are not waited for", func(t *testing.T) {
+		mock := &mockClient{
+			fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+				t.Fatalf("expected get to not be called for %s", resource.GetResourceType())
+				return nil, nil
+			},
+		}
+
+		c := &Civo{client: mock, logger: logger.None, waitTimeout: time.Second, waitInterval: time.Millisecond}
+
+		err := c.waitForDeletion(context.Background(), sdk.Network{ID: "1", Label: "network-1"})
+		testutils.AssertNoError(t, err)
+	})
+}
+
+func TestDeleteResourceWaits(t *testing.T) {
+	mock := &mockClient{
+		fnDelete: func(ctx context.Context, resource sdk.APIResource) error { return nil },
+		fnGet: func(ctx context.Context, resource sdk.APIResource) (sdk.APIResource, error) {
+			if resource.GetID() == "stuck" {
+				return resource, nil
+			}
+			return nil, sdk.ErrNotFound
+		},
+	}
+
+	var stdout bytes.Buffer
+	outputwriter.SetStdout(&stdout)
+	defer outputwriter.SetStdout(os.Stdout)
+
+	recorder := report.NewRecorder()
+	c := &Civo{client: mock, logger: logger.None, nuke: true, report: recorder, waitTimeout: 20 * time.Millisecond, waitInterval: time.Millisecond}
+
+	testutils.AssertNoError(t, c.deleteUnlessBlocked(context.Background(), sdk.Firewall{ID: "gone", Name: "gone"}))
+
+	err := c.deleteUnlessBlocked(context.Background(), sdk.Firewall{ID: "stuck", Name: "stuck"})
+
+	var timeoutErr *DeletionTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected a DeletionTimeoutError, got %v", err)
+	}
+
+	t.Run("only resources that are gone are reported as deleted", func(t *testing.T) {
+		testutils.AssertEqual(t, stdout.String(), "deleted firewall \"gone\"\n")
+	})
+
+	t.Run("resources still present are recorded as pending", func(t *testing.T) {
+		records := recorder.Report().Resources
+		testutils.AssertEqualf(t, 2, len(records), "expected a record per resource, got %v", records)
+		testutils.AssertEqual(t, records[0].Action, report.ActionDeleted)
+		testutils.AssertEqual(t, records[1].Action, report.ActionPending)
+		testutils.AssertEqual(t, records[1].Reason, timeoutErr.Error())
+	})
+}
@@ -1,7 +0,0 @@
-package compare
-
-import "strings"
-
-func ContainsIgnoreCase(s string, substr string) bool {
-	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
-}
@@ -0,0 +1,275 @@
+// Package config loads the dropkick configuration file, which holds named
+// profiles of credentials and options.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konstructio/dropkick/internal/protect"
+	"gopkg.in/yaml.v2"
+)
+
+// Providers supported by profiles.
+const (
+	ProviderCivo         = "civo"
+	ProviderDigitalOcean = "digitalocean"
+)
+
+// redacted replaces the value of secrets when profiles are encoded.
+const redacted = "<redacted>"
+
+// Config is the contents of the configuration file.
+type Config struct {
+	// DefaultProfile is the profile used when none is selected.
+	DefaultProfile string `yaml:"default_profile,omitempty"`
+
+	// Profiles are the named profiles.
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Profile is a named set of credentials and options for a provider.
+type Profile struct {
+	Provider    string         `yaml:"provider"`
+	Token       *Secret        `yaml:"token,omitempty"`
+	Regions     []string       `yaml:"regions,omitempty"`
+	Spaces      *Spaces        `yaml:"spaces,omitempty"`
+	Filters     Filters        `yaml:"filters,omitempty"`
+	ProtectFile string         `yaml:"protect_file,omitempty"`
+	Protect     *protect.Rules `yaml:"protect,omitempty"`
+}
+
+// Spaces are the DigitalOcean Spaces credentials and region.
+type Spaces struct {
+	AccessKey *Secret `yaml:"access_key,omitempty"`
+	SecretKey *Secret `yaml:"secret_key,omitempty"`
+	Region    string  `yaml:"region,omitempty"`
+}
+
+// Filters select which resources are processed, like the flags of the
+// same names.
+type Filters struct {
+	NameContains     []string `yaml:"name_contains,omitempty"`
+	NamePrefixes     []string `yaml:"name_prefixes,omitempty"`
+	NameGlobs        []string `yaml:"name_globs,omitempty"`
+	NameRegexes      []string `yaml:"name_regexes,omitempty"`
+	NameNotContains  []string `yaml:"name_not_contains,omitempty"`
+	NameNotPrefixes  []string `yaml:"name_not_prefixes,omitempty"`
+	NameNotGlobs     []string `yaml:"name_not_globs,omitempty"`
+	NameNotRegexes   []string `yaml:"name_not_regexes,omitempty"`
+	NameMatch        string   `yaml:"name_match,omitempty"`
+	OlderThan        string   `yaml:"older_than,omitempty"`
+	NewerThan        string   `yaml:"newer_than,omitempty"`
+	MissingCreatedAt string   `yaml:"missing_created_at,omitempty"`
+	Tags             []string `yaml:"tags,omitempty"`
+	WithoutTags      []string `yaml:"without_tags,omitempty"`
+	Only             []string `yaml:"only,omitempty"`
+	Skip             []string `yaml:"skip,omitempty"`
+}
+
+// Secret is a credential, either written in the configuration file, or
+// read from an environment variable or a file. In YAML, it's either a plain
+// string, or a mapping with exactly one of "value", "env" or "file".
+type Secret struct {
+	Value string `yaml:"value,omitempty"`
+	Env   string `yaml:"env,omitempty"`
+	File  string `yaml:"file,omitempty"`
+}
+
+// UnmarshalYAML decodes a secret from a plain string or a mapping, by
+// implementing the yaml.Unmarshaler interface.
+func (s *Secret) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err == nil {
+		*s = Secret{Value: value}
+		return nil
+	}
+
+	type plain Secret
+
+	var decoded plain
+	if err := unmarshal(&decoded); err != nil {
+		return err
+	}
+
+	set := 0
+	for _, v := range []string{decoded.Value, decoded.Env, decoded.File} {
+		if v != "" {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return errors.New(`a secret needs exactly one of "value", "env" or "file"`)
+	}
+
+	*s = Secret(decoded)
+	return nil
+}
+
+// MarshalYAML encodes a secret with its value redacted, by implementing the
+// yaml.Marshaler interface. The name of the environment variable or file
+// it's read from is kept.
+func (s Secret) MarshalYAML() (interface{}, error) {
+	if s.Value != "" {
+		return redacted, nil
+	}
+
+	type plain Secret
+	return plain(s), nil
+}
+
+// Resolve returns the value of the secret, reading it from its environment
+// variable or file if needed. Surrounding whitespace is removed.
+func (s *Secret) Resolve() (string, error) {
+	switch {
+	case s == nil:
+		return "", nil
+	case s.Env != "":
+		value := strings.TrimSpace(os.Getenv(s.Env))
+		if value == "" {
+			return "", fmt.Errorf("environment variable $%s is not set", s.Env)
+		}
+
+		return value, nil
+	case s.File != "":
+		contents, err := os.ReadFile(expandHome(s.File))
+		if err != nil {
+			return "", fmt.Errorf("unable to read secret file: %w", err)
+		}
+
+		return strings.TrimSpace(string(contents)), nil
+	default:
+		return s.Value, nil
+	}
+}
+
+// DefaultPath returns the path of the configuration file:
+// $XDG_CONFIG_HOME/dropkick/config.yaml, or ~/.config/dropkick/config.yaml
+// if XDG_CONFIG_HOME is not set.
+func DefaultPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "dropkick", "config.yaml"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".config", "dropkick", "config.yaml"), nil
+}
+
+// Load reads the configuration from a YAML file.
+func Load(filename string) (*Config, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file %q: %w", filename, err)
+	}
+
+	cfg, err := Parse(bytes.NewReader(contents))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse config file %q: %w", filename, err)
+	}
+
+	return cfg, nil
+}
+
+// Parse reads the configuration from YAML. It returns an error if unknown
+// fields are found, if a profile has an unknown provider, or if the default
+// profile doesn't exist.
+func Parse(r io.Reader) (*Config, error) {
+	var cfg Config
+
+	dec := yaml.NewDecoder(r)
+	dec.SetStrict(true)
+
+	if err := dec.Decode(&cfg); err != nil && err != io.EOF { //nolint:errorlint // the decoder returns io.EOF unwrapped
+		return nil, fmt.Errorf("unable to decode config: %w", err)
+	}
+
+	for name, profile := range cfg.Profiles {
+		if profile.Provider != ProviderCivo && profile.Provider != ProviderDigitalOcean {
+			return nil, fmt.Errorf("profile %q has unknown provider %q: expected %q or %q", name, profile.Provider, ProviderCivo, ProviderDigitalOcean)
+		}
+	}
+
+	if cfg.DefaultProfile != "" {
+		if _, ok := cfg.Profiles[cfg.DefaultProfile]; !ok {
+			return nil, fmt.Errorf("default profile %q not found", cfg.DefaultProfile)
+		}
+	}
+
+	return &cfg, nil
+}
+
+// Profile returns the profile with the given name, or the default profile
+// if the name is empty. It returns nil, and no error, if the name is empty
+// and there's no default profile.
+func (c *Config) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+
+	if name == "" {
+		return nil, nil //nolint:nilnil // no profile is valid and sets nothing
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found, expected one of: %s", name, strings.Join(c.names(), ", "))
+	}
+
+	return &profile, nil
+}
+
+// names returns the sorted names of the profiles.
+func (c *Config) names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// ProtectRules returns the protect rules of the profile: the rules written
+// in the profile merged with the rules of its protect file, if any.
+func (p *Profile) ProtectRules() (*protect.Rules, error) {
+	if p == nil {
+		return nil, nil //nolint:nilnil // nil rules are valid and protect nothing
+	}
+
+	if p.ProtectFile == "" {
+		return p.Protect, nil
+	}
+
+	rules, err := protect.Load(expandHome(p.ProtectFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load profile protect rules: %w", err)
+	}
+
+	return protect.Merge(p.Protect, rules), nil
+}
+
+// expandHome replaces a leading "~/" in a path with the home directory.
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(home, rest)
+}
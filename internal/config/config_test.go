@@ -0,0 +1,173 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+const testConfig = `
+default_profile: ci
+profiles:
+  ci:
+    provider: civo
+    token: plain-token
+    regions: [lon1, nyc1]
+    filters:
+      name_prefixes: [ci-]
+      older_than: 6h
+    protect:
+      ids: [abc-123]
+  do:
+    provider: digitalocean
+    token:
+      env: TEST_DROPKICK_TOKEN
+    spaces:
+      access_key: {value: access}
+      secret_key: {file: secret.txt}
+      region: nyc3
+`
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse(strings.NewReader(testConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("default profile", func(t *testing.T) {
+		profile, err := cfg.Profile("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if profile.Provider != ProviderCivo || len(profile.Regions) != 2 || profile.Filters.OlderThan != "6h" {
+			t.Fatalf("unexpected default profile: %+v", profile)
+		}
+
+		if _, ok := profile.Protect.Match("instance", "abc-123", "test"); !ok {
+			t.Fatalf("expecting the profile protect rules to protect by ID")
+		}
+	})
+
+	t.Run("named profile", func(t *testing.T) {
+		profile, err := cfg.Profile("do")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if profile.Token.Env != "TEST_DROPKICK_TOKEN" || profile.Spaces.AccessKey.Value != "access" || profile.Spaces.SecretKey.File != "secret.txt" {
+			t.Fatalf("unexpected profile: %+v", profile)
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		if _, err := cfg.Profile("prod"); err == nil || !strings.Contains(err.Error(), "ci, do") {
+			t.Fatalf("expecting an error listing the profiles, got %v", err)
+		}
+	})
+
+	t.Run("no default profile", func(t *testing.T) {
+		profile, err := (&Config{}).Profile("")
+		if err != nil || profile != nil {
+			t.Fatalf("expecting no profile and no error, got %+v (error: %v)", profile, err)
+		}
+	})
+
+	cases := map[string]string{
+		"unknown field":           "profiles: {ci: {provider: civo, colour: red}}",
+		"unknown provider":        "profiles: {ci: {provider: aws}}",
+		"missing default profile": "default_profile: prod\nprofiles: {ci: {provider: civo}}",
+		"ambiguous secret":        "profiles: {ci: {provider: civo, token: {env: A, file: b}}}",
+		"invalid protect rules":   "profiles: {ci: {provider: civo, protect: {name_regexes: ['(']}}}",
+	}
+
+	for name, contents := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(contents)); err == nil {
+				t.Fatalf("expecting an error, got nil")
+			}
+		})
+	}
+}
+
+func TestSecret(t *testing.T) {
+	t.Run("from the environment", func(t *testing.T) {
+		t.Setenv("TEST_DROPKICK_TOKEN", " from-env ")
+
+		value, err := (&Secret{Env: "TEST_DROPKICK_TOKEN"}).Resolve()
+		if err != nil || value != "from-env" {
+			t.Fatalf("expecting %q, got %q (error: %v)", "from-env", value, err)
+		}
+	})
+
+	t.Run("missing environment variable", func(t *testing.T) {
+		if _, err := (&Secret{Env: "TEST_DROPKICK_UNSET"}).Resolve(); err == nil {
+			t.Fatalf("expecting an error for an unset environment variable")
+		}
+	})
+
+	t.Run("from a file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "token")
+		if err := os.WriteFile(filename, []byte("from-file\n"), 0o600); err != nil {
+			t.Fatalf("unable to write token file: %v", err)
+		}
+
+		value, err := (&Secret{File: filename}).Resolve()
+		if err != nil || value != "from-file" {
+			t.Fatalf("expecting %q, got %q (error: %v)", "from-file", value, err)
+		}
+	})
+
+	t.Run("nil secret", func(t *testing.T) {
+		var s *Secret
+		if value, err := s.Resolve(); err != nil || value != "" {
+			t.Fatalf("expecting an empty value, got %q (error: %v)", value, err)
+		}
+	})
+
+	t.Run("values are redacted when encoded", func(t *testing.T) {
+		out, err := yaml.Marshal(Profile{Provider: ProviderCivo, Token: &Secret{Value: "plain-token"}, Spaces: &Spaces{AccessKey: &Secret{Env: "KEY"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if strings.Contains(string(out), "plain-token") || !strings.Contains(string(out), redacted) || !strings.Contains(string(out), "env: KEY") {
+			t.Fatalf("expecting the token to be redacted and the env variable to be kept, got:\n%s", out)
+		}
+	})
+}
+
+func TestProtectRules(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "protect.yaml")
+	if err := os.WriteFile(filename, []byte("name_globs: [prod-*]"), 0o600); err != nil {
+		t.Fatalf("unable to write protect file: %v", err)
+	}
+
+	cfg, err := Parse(strings.NewReader("profiles: {ci: {provider: civo, protect_file: " + filename + ", protect: {ids: [abc-123]}}}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	profile, _ := cfg.Profile("ci")
+
+	rules, err := profile.ProtectRules()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := rules.Match("instance", "abc-123", "test"); !ok {
+		t.Fatalf("expecting the inline rules to protect by ID")
+	}
+
+	if _, ok := rules.Match("instance", "1", "prod-db"); !ok {
+		t.Fatalf("expecting the protect file rules to protect by name glob")
+	}
+
+	var none *Profile
+	if rules, err := none.ProtectRules(); err != nil || rules != nil {
+		t.Fatalf("expecting no rules for no profile, got %+v (error: %v)", rules, err)
+	}
+}
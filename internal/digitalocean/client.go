@@ -4,25 +4,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/digitalocean/godo"
+	"github.com/konstructio/dropkick/internal/age"
 	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/matcher"
+	"github.com/konstructio/dropkick/internal/protect"
+	"github.com/konstructio/dropkick/internal/report"
 )
 
 // DigitalOcean is a client for the DigitalOcean API.
 type DigitalOcean struct {
-	client          *godo.Client   // The underlying DigitalOcean API client.
-	s3svc           *s3.S3         // The underlying DigitalOcean Spaces API client.
-	nuke            bool           // Whether to nuke resources.
-	token           string         // The API token.
-	logger          *logger.Logger // The logger instance.
-	spacesAccessKey string         // The access key for Spaces.
-	spacesSecretKey string         // The secret key for Spaces.
-	spacesRegion    string         // The region for Spaces.
+	client          *godo.Client     // The underlying DigitalOcean API client.
+	s3svc           *s3.S3           // The underlying DigitalOcean Spaces API client.
+	nuke            bool             // Whether to nuke resources.
+	token           string           // The API token.
+	logger          *logger.Logger   // The logger instance.
+	spacesAccessKey string           // The access key for Spaces.
+	spacesSecretKey string           // The secret key for Spaces.
+	spacesRegion    string           // The region for Spaces.
+	protect         *protect.Rules   // Resources matching these rules are never deleted.
+	names           *matcher.Matcher // If set, only resources with a name selected by this matcher are deleted.
+	age             *age.Filter      // If set, only resources with a creation time selected by this filter are deleted.
+	expiring        bool             // If set, only resources whose expiry tags say they have expired are deleted.
+	report          *report.Recorder // If set, a record of what was done with every resource found is added to it.
 }
 
 // Option is a function that configures a DigitalOcean.
@@ -52,6 +62,39 @@ func WithNuke(nuke bool) Option {
 	}
 }
 
+// WithProtect sets the rules of resources a DigitalOcean must never delete.
+func WithProtect(rules *protect.Rules) Option {
+	return func(c *DigitalOcean) error {
+		c.protect = rules
+		return nil
+	}
+}
+
+// WithNameMatcher sets the name matcher for a DigitalOcean.
+func WithNameMatcher(names *matcher.Matcher) Option {
+	return func(c *DigitalOcean) error {
+		c.names = names
+		return nil
+	}
+}
+
+// WithAgeFilter sets the age filter for a DigitalOcean.
+func WithAgeFilter(filter *age.Filter) Option {
+	return func(c *DigitalOcean) error {
+		c.age = filter
+		return nil
+	}
+}
+
+// WithRecorder sets the recorder a DigitalOcean adds a record to for every
+// resource it finds, describing what was done with it.
+func WithRecorder(recorder *report.Recorder) Option {
+	return func(c *DigitalOcean) error {
+		c.report = recorder
+		return nil
+	}
+}
+
 func WithS3Storage(accessKey, secretKey, region string) Option {
 	return func(c *DigitalOcean) error {
 		c.spacesAccessKey = accessKey
@@ -61,6 +104,39 @@ func WithS3Storage(accessKey, secretKey, region string) Option {
 	}
 }
 
+// isProtected checks if a resource matches the protect rules, and logs
+// that it's being skipped if it does.
+func (d *DigitalOcean) isProtected(r resource) bool {
+	rule, ok := d.protect.Match(r.kind, r.id, r.name)
+	if ok {
+		d.protected(r, rule)
+	}
+
+	return ok
+}
+
+// isNameSelected checks if a resource name is selected by the name matcher,
+// and logs that it's being skipped if it isn't.
+func (d *DigitalOcean) isNameSelected(r resource) bool {
+	ok, reason := d.names.Match(r.name)
+	if !ok {
+		d.skip(r, reason)
+	}
+
+	return ok
+}
+
+// isAgeSelected checks if a resource creation time is selected by the age
+// filter, and logs that it's being skipped if it isn't.
+func (d *DigitalOcean) isAgeSelected(r resource, createdAt time.Time) bool {
+	ok, reason := d.age.Match(createdAt)
+	if !ok {
+		d.skip(r, reason)
+	}
+
+	return ok
+}
+
 // New creates a new DigitalOcean with the given options.
 // It returns an error if the token or region is not set, or if it fails to
 // create the underlying DigitalOcean API client.
@@ -120,6 +196,8 @@ func New(ctx context.Context, opts ...Option) (*DigitalOcean, error) {
 		c.logger = logger.None
 	}
 
+	c.logger = c.logger.With("provider", "digitalocean")
+
 	return c, nil
 }
 
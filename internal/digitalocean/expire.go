@@ -0,0 +1,49 @@
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/expiry"
+	"github.com/konstructio/dropkick/internal/report"
+)
+
+// Expire deletes the Kubernetes clusters and volumes whose dropkick-ttl or
+// dropkick-expires tag says they have expired. Resources without one of
+// those tags, and Spaces buckets, which don't support tags, are left alone.
+// The nuke setting is still required to actually delete the expired
+// resources.
+func (d *DigitalOcean) Expire(ctx context.Context) error {
+	d.expiring = true
+	defer func() { d.expiring = false }()
+
+	if err := d.NukeKubernetesClusters(ctx); err != nil {
+		return fmt.Errorf("unable to expire Kubernetes clusters: %w", err)
+	}
+
+	if err := d.NukeVolumes(ctx); err != nil {
+		return fmt.Errorf("unable to expire volumes: %w", err)
+	}
+
+	return nil
+}
+
+// isExpired checks, when expiring resources, if a resource has expired
+// according to its tags, and logs the decision. When not expiring resources
+// every resource is considered expired.
+func (d *DigitalOcean) isExpired(r resource, tags []string, createdAt time.Time) bool {
+	if !d.expiring {
+		return true
+	}
+
+	expired, reason := expiry.Check(tags, createdAt, time.Now())
+	if !expired {
+		d.resourceLogger(r).Infof("skipping %s %q: %s", r.kind, r.name, reason)
+		d.record(r, false, report.ActionSkipped, reason)
+		return false
+	}
+
+	d.resourceLogger(r).Infof("%s %q is expired: %s", r.kind, r.name, reason)
+	return true
+}
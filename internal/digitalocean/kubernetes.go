@@ -27,18 +27,36 @@ func (d *DigitalOcean) NukeKubernetesClusters(ctx context.Context) error {
 		d.logger.Infof("found %d clusters", len(clusters))
 
 		for _, cluster := range clusters {
-			d.logger.Infof("found cluster: name: %q - ID: %q", cluster.Name, cluster.ID)
+			clusterResource := resource{kind: "kubernetes cluster", id: cluster.ID, name: cluster.Name, region: cluster.RegionSlug}
+			d.resourceLogger(clusterResource).Infof("found cluster: name: %q - ID: %q", cluster.Name, cluster.ID)
+
+			// A protected cluster keeps its load balancers, volumes and snapshots too
+			if d.isProtected(clusterResource) {
+				continue
+			}
+
+			// A selected cluster takes its load balancers, volumes and snapshots
+			// with it, whatever their names and ages are
+			if !d.isNameSelected(clusterResource) || !d.isAgeSelected(clusterResource, cluster.CreatedAt) {
+				continue
+			}
+
+			if !d.isExpired(clusterResource, cluster.Tags, cluster.CreatedAt) {
+				continue
+			}
 
 			// Delete the Kubernetes cluster
 			if d.nuke {
-				d.logger.Infof("deleting cluster %q", cluster.ID)
+				d.resourceLogger(clusterResource).Infof("deleting cluster %q", cluster.ID)
 				_, err := d.client.Kubernetes.Delete(ctx, cluster.ID)
+				d.recordDeletion(clusterResource, err)
 				if err != nil {
 					return fmt.Errorf("unable to delete cluster %q: %w", cluster.ID, err)
 				}
 				outputwriter.WriteStdoutf("deleted cluster %q", cluster.ID)
 			} else {
-				d.logger.Warnf("refusing to delete cluster %q: nuke is not enabled", cluster.ID)
+				d.resourceLogger(clusterResource).Warnf("refusing to delete cluster %q: nuke is not enabled", cluster.ID)
+				d.recordRefusal(clusterResource)
 			}
 
 			foo, _, err := d.client.Kubernetes.ListAssociatedResourcesForDeletion(ctx, cluster.ID)
@@ -46,47 +64,68 @@ func (d *DigitalOcean) NukeKubernetesClusters(ctx context.Context) error {
 				return fmt.Errorf("unable to list associated resources for cluster %q: %w", cluster.ID, err)
 			}
 
-			d.logger.Infof("found %d load balancers, %d volumes, and %d volume snapshots for cluster %q", len(foo.LoadBalancers), len(foo.Volumes), len(foo.VolumeSnapshots), cluster.Name)
+			d.resourceLogger(clusterResource).Infof("found %d load balancers, %d volumes, and %d volume snapshots for cluster %q", len(foo.LoadBalancers), len(foo.Volumes), len(foo.VolumeSnapshots), cluster.Name)
 
 			// Delete load balancers associated with this cluster
 			for _, loadbalancer := range foo.LoadBalancers {
+				lbResource := resource{kind: "load balancer", id: loadbalancer.ID, name: loadbalancer.Name, region: cluster.RegionSlug}
+				if d.isProtected(lbResource) {
+					continue
+				}
+
 				if d.nuke {
-					d.logger.Infof("deleting loadbalancer %q for cluster %q", loadbalancer.ID, cluster.ID)
+					d.resourceLogger(lbResource).Infof("deleting loadbalancer %q for cluster %q", loadbalancer.ID, cluster.ID)
 					_, err := d.client.LoadBalancers.Delete(ctx, loadbalancer.ID)
+					d.recordDeletion(lbResource, err)
 					if err != nil {
 						return fmt.Errorf("unable to delete cluster %q loadbalancer %q: %w", cluster.ID, loadbalancer.ID, err)
 					}
 					outputwriter.WriteStdoutf("deleted loadbalancer %q for cluster %q", loadbalancer.ID, cluster.ID)
 				} else {
-					d.logger.Warnf("refusing to delete loadbalancer %q for cluster %q: nuke is not enabled", loadbalancer.ID, cluster.ID)
+					d.resourceLogger(lbResource).Warnf("refusing to delete loadbalancer %q for cluster %q: nuke is not enabled", loadbalancer.ID, cluster.ID)
+					d.recordRefusal(lbResource)
 				}
 			}
 
 			// Delete volumes associated with this cluster
 			for _, volume := range foo.Volumes {
+				volumeResource := resource{kind: "volume", id: volume.ID, name: volume.Name, region: cluster.RegionSlug}
+				if d.isProtected(volumeResource) {
+					continue
+				}
+
 				if d.nuke {
-					d.logger.Infof("deleting volume %q for cluster %q", volume.ID, cluster.ID)
+					d.resourceLogger(volumeResource).Infof("deleting volume %q for cluster %q", volume.ID, cluster.ID)
 					_, err := d.client.Storage.DeleteVolume(ctx, volume.ID)
+					d.recordDeletion(volumeResource, err)
 					if err != nil {
 						return fmt.Errorf("unable to delete cluster %q volume %q: %w", cluster.ID, volume.ID, err)
 					}
 					outputwriter.WriteStdoutf("deleted volume %q for cluster %q", volume.ID, cluster.ID)
 				} else {
-					d.logger.Warnf("refusing to delete volume %q for cluster %q: nuke is not enabled", volume.ID, cluster.ID)
+					d.resourceLogger(volumeResource).Warnf("refusing to delete volume %q for cluster %q: nuke is not enabled", volume.ID, cluster.ID)
+					d.recordRefusal(volumeResource)
 				}
 			}
 
 			// Delete volume snapshots associated with this cluster
 			for _, snapshot := range foo.VolumeSnapshots {
+				snapshotResource := resource{kind: "volume snapshot", id: snapshot.ID, name: snapshot.Name, region: cluster.RegionSlug}
+				if d.isProtected(snapshotResource) {
+					continue
+				}
+
 				if d.nuke {
-					d.logger.Infof("deleting volume snapshot %q for cluster %q", snapshot.ID, cluster.ID)
+					d.resourceLogger(snapshotResource).Infof("deleting volume snapshot %q for cluster %q", snapshot.ID, cluster.ID)
 					_, err := d.client.Snapshots.Delete(ctx, snapshot.ID)
+					d.recordDeletion(snapshotResource, err)
 					if err != nil {
 						return fmt.Errorf("unable to delete cluster %q volume snapshot %q: %w", cluster.ID, snapshot.ID, err)
 					}
 					outputwriter.WriteStdoutf("deleted volume snapshot %q for cluster %q", snapshot.ID, cluster.ID)
 				} else {
-					d.logger.Warnf("refusing to delete volume snapshot %q for cluster %q: nuke is not enabled", snapshot.ID, cluster.ID)
+					d.resourceLogger(snapshotResource).Warnf("refusing to delete volume snapshot %q for cluster %q: nuke is not enabled", snapshot.ID, cluster.ID)
+					d.recordRefusal(snapshotResource)
 				}
 			}
 		}
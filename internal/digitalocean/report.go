@@ -0,0 +1,65 @@
+package digitalocean
+
+import (
+	"github.com/konstructio/dropkick/internal/logger"
+	"github.com/konstructio/dropkick/internal/report"
+)
+
+// resource identifies a DigitalOcean resource in logs and reports.
+type resource struct {
+	kind   string // the type of the resource, like "volume"
+	id     string
+	name   string
+	region string
+}
+
+// resourceLogger returns a logger adding the type, ID and region of the
+// resource as attributes to every entry.
+func (d *DigitalOcean) resourceLogger(r resource) *logger.Logger {
+	return d.logger.With("type", r.kind, "id", r.id, "region", r.region)
+}
+
+// record adds a record about what was done with a resource to the report,
+// if one is being collected.
+func (d *DigitalOcean) record(r resource, selected bool, action report.Action, reason string) {
+	d.report.Add(report.Record{
+		Provider: "digitalocean",
+		Region:   r.region,
+		Type:     r.kind,
+		ID:       r.id,
+		Name:     r.name,
+		Selected: selected,
+		Action:   action,
+		Reason:   reason,
+	})
+}
+
+// skip logs that a resource is skipped, and why, and records it.
+func (d *DigitalOcean) skip(r resource, reason string) {
+	d.resourceLogger(r).Warnf("skipping %s %q: %s", r.kind, r.name, reason)
+	d.record(r, false, report.ActionSkipped, reason)
+}
+
+// protected logs that a resource is skipped because it matches a protect
+// rule, and records it.
+func (d *DigitalOcean) protected(r resource, rule string) {
+	reason := "it is protected by rule " + rule
+	d.resourceLogger(r).Warnf("skipping %s %q: %s", r.kind, r.name, reason)
+	d.record(r, false, report.ActionProtected, reason)
+}
+
+// recordDeletion records the outcome of deleting a selected resource.
+func (d *DigitalOcean) recordDeletion(r resource, err error) {
+	if err != nil {
+		d.record(r, true, report.ActionFailed, err.Error())
+		return
+	}
+
+	d.record(r, true, report.ActionDeleted, "")
+}
+
+// recordRefusal records that a selected resource wasn't deleted because
+// nuke is not enabled.
+func (d *DigitalOcean) recordRefusal(r resource) {
+	d.record(r, true, report.ActionRefused, "nuke is not enabled")
+}
@@ -3,6 +3,7 @@ package digitalocean
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/konstructio/dropkick/internal/outputwriter"
 )
@@ -18,7 +19,18 @@ func (d *DigitalOcean) NukeS3Storage() error {
 	d.logger.Infof("found %d Space buckets", len(resp.Buckets))
 
 	for _, bucket := range resp.Buckets {
-		d.logger.Infof("found Space bucket %q, region %q", *bucket.Name, d.spacesRegion)
+		bucketResource := resource{kind: "space bucket", id: *bucket.Name, name: *bucket.Name, region: d.spacesRegion}
+		d.resourceLogger(bucketResource).Infof("found Space bucket %q, region %q", *bucket.Name, d.spacesRegion)
+
+		// A protected bucket keeps its objects too
+
+		if d.isProtected(bucketResource) {
+			continue
+		}
+
+		if !d.isNameSelected(bucketResource) || !d.isAgeSelected(bucketResource, aws.TimeValue(bucket.CreationDate)) {
+			continue
+		}
 
 		objs, err := d.s3svc.ListObjectsV2(&s3.ListObjectsV2Input{
 			Bucket: bucket.Name,
@@ -29,7 +41,7 @@ func (d *DigitalOcean) NukeS3Storage() error {
 
 		for _, obj := range objs.Contents {
 			if d.nuke {
-				d.logger.Infof("deleting object %q from Space bucket %q, region %q", *obj.Key, *bucket.Name, d.spacesRegion)
+				d.resourceLogger(bucketResource).Infof("deleting object %q from Space bucket %q, region %q", *obj.Key, *bucket.Name, d.spacesRegion)
 				_, err := d.s3svc.DeleteObject(&s3.DeleteObjectInput{
 					Bucket: bucket.Name,
 					Key:    obj.Key,
@@ -39,21 +51,23 @@ func (d *DigitalOcean) NukeS3Storage() error {
 				}
 				outputwriter.WriteStdoutf("deleted object %q from Space bucket %q, region %q", *obj.Key, *bucket.Name, d.spacesRegion)
 			} else {
-				d.logger.Warnf("refusing to delete object %q from Space bucket %q, region %q: nuke is not enabled", *obj.Key, *bucket.Name, d.spacesRegion)
+				d.resourceLogger(bucketResource).Warnf("refusing to delete object %q from Space bucket %q, region %q: nuke is not enabled", *obj.Key, *bucket.Name, d.spacesRegion)
 			}
 		}
 
 		if d.nuke {
-			d.logger.Infof("deleting Space bucket %q, region %q", *bucket.Name, d.spacesRegion)
+			d.resourceLogger(bucketResource).Infof("deleting Space bucket %q, region %q", *bucket.Name, d.spacesRegion)
 			_, err := d.s3svc.DeleteBucket(&s3.DeleteBucketInput{
 				Bucket: bucket.Name,
 			})
+			d.recordDeletion(bucketResource, err)
 			if err != nil {
 				return fmt.Errorf("unable to delete Space bucket %q, region %q: %w", *bucket.Name, d.spacesRegion, err)
 			}
 			outputwriter.WriteStdoutf("deleted Space bucket %q, region %q", *bucket.Name, d.spacesRegion)
 		} else {
-			d.logger.Warnf("refusing to delete Space bucket %q, region %q: nuke is not enabled\n", *bucket.Name, d.spacesRegion)
+			d.resourceLogger(bucketResource).Warnf("refusing to delete Space bucket %q, region %q: nuke is not enabled", *bucket.Name, d.spacesRegion)
+			d.recordRefusal(bucketResource)
 		}
 	}
 
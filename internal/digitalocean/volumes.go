@@ -19,17 +19,35 @@ func (d *DigitalOcean) NukeVolumes(ctx context.Context) error {
 	d.logger.Infof("found %d volumes", len(volumes))
 
 	for _, volume := range volumes {
-		d.logger.Infof("found volume %q", volume.ID)
+		volumeResource := resource{kind: "volume", id: volume.ID, name: volume.Name}
+		if volume.Region != nil {
+			volumeResource.region = volume.Region.Slug
+		}
+		d.resourceLogger(volumeResource).Infof("found volume %q", volume.ID)
+
+		if d.isProtected(volumeResource) {
+			continue
+		}
+
+		if !d.isNameSelected(volumeResource) || !d.isAgeSelected(volumeResource, volume.CreatedAt) {
+			continue
+		}
+
+		if !d.isExpired(volumeResource, volume.Tags, volume.CreatedAt) {
+			continue
+		}
 
 		if d.nuke {
-			d.logger.Infof("deleting volume %q", volume.ID)
+			d.resourceLogger(volumeResource).Infof("deleting volume %q", volume.ID)
 			_, err := d.client.Storage.DeleteVolume(ctx, volume.ID)
+			d.recordDeletion(volumeResource, err)
 			if err != nil {
 				return fmt.Errorf("unable to delete volume %s: %w", volume.ID, err)
 			}
 			outputwriter.WriteStdoutf("deleted volume %q", volume.ID)
 		} else {
-			d.logger.Warnf("refusing to delete volume %s: nuke is not enabled", volume.ID)
+			d.resourceLogger(volumeResource).Warnf("refusing to delete volume %s: nuke is not enabled", volume.ID)
+			d.recordRefusal(volumeResource)
 		}
 	}
 
@@ -0,0 +1,101 @@
+package expiry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konstructio/dropkick/internal/tags"
+)
+
+const (
+	// TTLTag is the tag holding how long a resource may live after its
+	// creation, like "dropkick-ttl=4h".
+	TTLTag = "dropkick-ttl"
+
+	// ExpiresTag is the tag holding when a resource expires, like
+	// "dropkick-expires=2026-10-20T00:00Z".
+	ExpiresTag = "dropkick-expires"
+)
+
+// expiresLayouts are the layouts accepted in the ExpiresTag, in the order
+// they're tried. Seconds and the time of day are optional, since tags are
+// usually written by hand or by provisioning scripts.
+var expiresLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// At returns when a resource with the given tags and creation time expires.
+// The ok result is false when the resource has no expiry tag, in which case
+// it must be left alone. An error is returned if the tag value can't be
+// parsed, or if the resource has a TTL but its creation time is unknown.
+// When both tags are set, the earliest expiry wins.
+func At(resourceTags []string, createdAt time.Time) (time.Time, bool, error) {
+	var (
+		expiresAt time.Time
+		found     bool
+	)
+
+	if value, ok := tags.Lookup(resourceTags, TTLTag); ok {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s tag %q: %w", TTLTag, value, err)
+		}
+
+		if createdAt.IsZero() {
+			return time.Time{}, false, fmt.Errorf("%s tag %q can't be applied: the creation time is unknown", TTLTag, value)
+		}
+
+		expiresAt, found = createdAt.Add(ttl), true
+	}
+
+	if value, ok := tags.Lookup(resourceTags, ExpiresTag); ok {
+		t, err := parseExpires(value)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		if !found || t.Before(expiresAt) {
+			expiresAt = t
+		}
+
+		found = true
+	}
+
+	return expiresAt, found, nil
+}
+
+// parseExpires parses the value of an ExpiresTag. Times without a time zone
+// are in UTC.
+func parseExpires(value string) (time.Time, error) {
+	for _, layout := range expiresLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid %s tag %q: expected a time like %q", ExpiresTag, value, "2026-10-20T00:00Z")
+}
+
+// Check decides whether a resource with the given tags and creation time
+// has expired at the given time. It also returns the reason for the
+// decision, which can be used in log messages.
+func Check(resourceTags []string, createdAt, now time.Time) (bool, string) {
+	expiresAt, ok, err := At(resourceTags, createdAt)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	if !ok {
+		return false, fmt.Sprintf("it has no %s or %s tag", TTLTag, ExpiresTag)
+	}
+
+	if now.Before(expiresAt) {
+		return false, fmt.Sprintf("it expires in %s, at %s", expiresAt.Sub(now).Round(time.Second), expiresAt.UTC().Format(time.RFC3339))
+	}
+
+	return true, fmt.Sprintf("it expired %s ago, at %s", now.Sub(expiresAt).Round(time.Second), expiresAt.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,45 @@
+package expiry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-5 * time.Hour)
+
+	cases := []struct {
+		name      string
+		tags      []string
+		createdAt time.Time
+		want      bool
+	}{
+		{name: "no tags", tags: nil, createdAt: created, want: false},
+		{name: "unrelated tags", tags: []string{"env=ci"}, createdAt: created, want: false},
+		{name: "ttl elapsed", tags: []string{"dropkick-ttl=4h"}, createdAt: created, want: true},
+		{name: "ttl not elapsed", tags: []string{"dropkick-ttl=6h"}, createdAt: created, want: false},
+		{name: "ttl with colon separator", tags: []string{"dropkick-ttl:4h"}, createdAt: created, want: true},
+		{name: "ttl without creation time", tags: []string{"dropkick-ttl=4h"}, want: false},
+		{name: "invalid ttl", tags: []string{"dropkick-ttl=soon"}, createdAt: created, want: false},
+		{name: "expires passed", tags: []string{"dropkick-expires=2026-10-17T11:00Z"}, want: true},
+		{name: "expires not passed", tags: []string{"dropkick-expires=2026-10-20T00:00Z"}, want: false},
+		{name: "expires with seconds", tags: []string{"dropkick-expires=2026-10-17T11:59:59Z"}, want: true},
+		{name: "expires date only", tags: []string{"dropkick-expires:2026-10-17"}, want: true},
+		{name: "invalid expires", tags: []string{"dropkick-expires=tomorrow"}, want: false},
+		{name: "earliest of both wins", tags: []string{"dropkick-ttl=48h", "dropkick-expires=2026-10-17T00:00Z"}, createdAt: created, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, reason := Check(tc.tags, tc.createdAt, now)
+			if got != tc.want {
+				t.Fatalf("expecting expired to be %v, got %v (reason: %q)", tc.want, got, reason)
+			}
+
+			if reason == "" {
+				t.Fatalf("expecting a reason for the decision")
+			}
+		})
+	}
+}
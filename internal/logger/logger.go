@@ -1,54 +1,141 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"time"
+	"log/slog"
+	"os"
+	"strings"
 
-	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 )
 
-var (
-	colorCyan   = color.New(color.FgCyan).SprintFunc()
-	colorYellow = color.New(color.FgYellow).SprintFunc()
-	colorRed    = color.New(color.FgRed).SprintFunc()
+// Format is the format log entries are written in.
+type Format string
+
+const (
+	FormatText Format = "text" // one human-readable line per entry, coloured on terminals
+	FormatJSON Format = "json" // one JSON object per entry
 )
 
-// Logger is a struct that wraps the standard library's log.Logger
+// ParseFormat converts a string into a Format. It returns an error if the
+// string is not a known format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatText, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown log format %q: expected text or json", s)
+	}
+}
+
+// ParseLevel converts a string into a log level. It returns an error if the
+// string is not one of debug, info, warn or error.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q: expected debug, info, warn or error", s)
+	}
+}
+
+// Options configures a Logger created with NewWithOptions.
+type Options struct {
+	Level  slog.Level // entries below this level are discarded
+	Format Format     // the format entries are written in, text if empty
+}
+
+// Logger writes leveled log entries, with structured attributes, through a
+// slog.Logger.
 type Logger struct {
-	log io.Writer
+	log *slog.Logger // nil discards everything
 }
 
-// None is a default Logger instance with no underlying log.Logger
+// None is a default Logger instance that discards everything.
 var None = &Logger{}
 
-// New creates a new Logger instance. It takes an io.Writer as the destination
-// where it will write log entries. The log entries will be prefixed with the date and time.
+// New creates a new Logger instance writing info and higher entries to dest
+// in the text format. Entries are prefixed with the date and time, and
+// coloured if dest is a terminal and NO_COLOR is not set.
 func New(dest io.Writer) *Logger {
-	return &Logger{log: dest}
+	return NewWithOptions(dest, Options{Level: slog.LevelInfo, Format: FormatText})
+}
+
+// NewWithOptions creates a new Logger instance writing to dest with the
+// given options.
+func NewWithOptions(dest io.Writer, opts Options) *Logger {
+	if opts.Format == FormatJSON {
+		return &Logger{log: slog.New(slog.NewJSONHandler(dest, &slog.HandlerOptions{Level: opts.Level}))}
+	}
+
+	return &Logger{log: slog.New(newTextHandler(dest, opts.Level, colorEnabled(dest)))}
+}
+
+// colorEnabled reports whether entries written to dest should be coloured:
+// only if dest is a terminal and the NO_COLOR environment variable is not
+// set, following https://no-color.org.
+func colorEnabled(dest io.Writer) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	f, ok := dest.(*os.File)
+	if !ok {
+		return false
+	}
+
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// With returns a Logger adding the given attributes, as key-value pairs or
+// slog.Attr values, to every entry.
+func (l *Logger) With(args ...any) *Logger {
+	if l == nil || l.log == nil {
+		return l
+	}
+
+	return &Logger{log: l.log.With(args...)}
+}
+
+// Debugf formats according to a format specifier and writes a debug entry.
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	l.logf(slog.LevelDebug, format, v...)
 }
 
-// Infof formats according to a format specifier and writes to the logger's io.Writer.
+// Infof formats according to a format specifier and writes an info entry.
 func (l *Logger) Infof(format string, v ...interface{}) {
-	l.write(colorCyan(fmt.Sprintf(format, v...)))
+	l.logf(slog.LevelInfo, format, v...)
 }
 
-// Warnf formats according to a format specifier and writes to the logger's io.Writer.
+// Warnf formats according to a format specifier and writes a warning entry.
 func (l *Logger) Warnf(format string, v ...interface{}) {
-	l.write(colorYellow(fmt.Sprintf(format, v...)))
+	l.logf(slog.LevelWarn, format, v...)
 }
 
-// Errorf formats according to a format specifier and writes to the logger's io.Writer.
+// Errorf formats according to a format specifier and writes an error entry.
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	l.write(colorRed(fmt.Sprintf(format, v...)))
+	l.logf(slog.LevelError, format, v...)
 }
 
-// printf is an internal method that writes to the logger's io.Writer or does
-// nothing if the logger's io.Writer is nil.
-func (l *Logger) write(contents string) {
-	if l.log == nil {
+// logf is an internal method that writes an entry at the given level, or
+// does nothing if the logger discards everything or the level is disabled.
+func (l *Logger) logf(level slog.Level, format string, v ...interface{}) {
+	if l == nil || l.log == nil {
+		return
+	}
+
+	ctx := context.Background()
+	if !l.log.Enabled(ctx, level) {
 		return
 	}
 
-	fmt.Fprint(l.log, time.Now().Format("2006/01/02 15:04:05")+" "+contents+"\n")
+	l.log.Log(ctx, level, fmt.Sprintf(format, v...))
 }
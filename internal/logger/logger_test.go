@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf).With("type", "instance", "id", "abc-123")
+
+	log.Infof("deleting %s %q", "instance", "my instance")
+
+	line := strings.TrimSpace(buf.String())
+	wantSuffix := `INFO  deleting instance "my instance" type=instance id=abc-123`
+	if !strings.HasSuffix(line, wantSuffix) {
+		t.Fatalf("expecting a line ending with %q, got %q", wantSuffix, line)
+	}
+
+	if strings.Contains(line, "\x1b[") {
+		t.Fatalf("expecting no colours when not writing to a terminal, got %q", line)
+	}
+}
+
+func TestTextQuotesValues(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf).With("name", "two words", "empty", "").Warnf("skipping")
+
+	if !strings.Contains(buf.String(), `name="two words" empty=""`) {
+		t.Fatalf("expecting quoted values, got %q", buf.String())
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(&buf, Options{Level: slog.LevelInfo, Format: FormatJSON}).With("region", "lon1")
+
+	log.Errorf("unable to delete %s", "volume")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unable to decode JSON entry %q: %v", buf.String(), err)
+	}
+
+	if entry["msg"] != "unable to delete volume" || entry["level"] != "ERROR" || entry["region"] != "lon1" {
+		t.Fatalf("unexpected JSON entry: %v", entry)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(&buf, Options{Level: slog.LevelWarn})
+
+	log.Debugf("debug")
+	log.Infof("info")
+	log.Warnf("warn")
+	log.Errorf("error")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "WARN") || !strings.Contains(lines[1], "ERROR") {
+		t.Fatalf("expecting only the warning and error entries, got %q", buf.String())
+	}
+}
+
+func TestNone(t *testing.T) {
+	// None and the loggers derived from it must discard everything
+	// without panicking.
+	None.With("type", "instance").Infof("nothing")
+	None.Debugf("nothing")
+}
+
+func TestConcurrentEntries(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf)
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			log.With("worker", i).Infof("working")
+		}()
+	}
+	wg.Wait()
+
+	if got := strings.Count(buf.String(), "\n"); got != 50 {
+		t.Fatalf("expecting 50 entries, got %d", got)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for in, want := range map[string]slog.Level{"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "warn": slog.LevelWarn, "error": slog.LevelError} {
+		got, err := ParseLevel(in)
+		if err != nil || got != want {
+			t.Fatalf("expecting level %v for %q, got %v (error: %v)", want, in, got, err)
+		}
+	}
+
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Fatalf("expecting an error for an unknown level")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("JSON"); err != nil || f != FormatJSON {
+		t.Fatalf("expecting json, got %q (error: %v)", f, err)
+	}
+
+	if _, err := ParseFormat("logfmt"); err == nil {
+		t.Fatalf("expecting an error for an unknown format")
+	}
+}
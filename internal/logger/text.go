@@ -0,0 +1,157 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/fatih/color"
+)
+
+// textHandler is a slog.Handler writing one human-readable line per entry:
+// the date and time, the level, the message and then the attributes as
+// key=value pairs. The level and message are coloured when color is set.
+type textHandler struct {
+	mu     *sync.Mutex // ensures concurrent entries are written one at a time, shared with derived handlers
+	w      io.Writer
+	level  slog.Leveler
+	colors map[slog.Level]*color.Color // nil when colours are disabled
+	attrs  []byte                      // the preformatted attributes added with WithAttrs
+	prefix string                      // the group prefix added to attribute keys
+}
+
+// newTextHandler creates a textHandler writing entries at or above level
+// to w.
+func newTextHandler(w io.Writer, level slog.Leveler, colored bool) *textHandler {
+	h := &textHandler{mu: &sync.Mutex{}, w: w, level: level}
+
+	if colored {
+		h.colors = map[slog.Level]*color.Color{
+			slog.LevelDebug: color.New(color.Faint),
+			slog.LevelInfo:  color.New(color.FgCyan),
+			slog.LevelWarn:  color.New(color.FgYellow),
+			slog.LevelError: color.New(color.FgRed),
+		}
+
+		// The global color setting only looks at stdout, but entries are
+		// usually written to stderr, which was checked by the caller.
+		for _, c := range h.colors {
+			c.EnableColor()
+		}
+	}
+
+	return h
+}
+
+// Enabled implements slog.Handler.
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implements slog.Handler.
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+
+	buf.WriteString(r.Time.Format("2006/01/02 15:04:05"))
+	buf.WriteByte(' ')
+
+	line := padLevel(r.Level) + " " + r.Message
+	if c, ok := h.colors[r.Level]; ok {
+		line = c.Sprint(line)
+	}
+
+	buf.WriteString(line)
+	buf.Write(h.attrs)
+
+	r.Attrs(func(a slog.Attr) bool {
+		appendAttr(&buf, h.prefix, a)
+		return true
+	})
+
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := h.w.Write(buf.Bytes())
+	return err //nolint:wrapcheck // the error comes straight from the writer
+}
+
+// WithAttrs implements slog.Handler.
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var buf bytes.Buffer
+	buf.Write(h.attrs)
+
+	for _, a := range attrs {
+		appendAttr(&buf, h.prefix, a)
+	}
+
+	derived := *h
+	derived.attrs = buf.Bytes()
+	return &derived
+}
+
+// WithGroup implements slog.Handler.
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	derived := *h
+	derived.prefix = h.prefix + name + "."
+	return &derived
+}
+
+// padLevel returns the name of the level, padded so messages are aligned.
+func padLevel(level slog.Level) string {
+	return strings.ToUpper(level.String()) + strings.Repeat(" ", max(0, 5-len(level.String())))
+}
+
+// appendAttr appends an attribute to buf as " key=value", quoting the value
+// if it's empty or contains spaces, quotes or control characters. Groups
+// are flattened with dotted keys.
+func appendAttr(buf *bytes.Buffer, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+
+		for _, ga := range a.Value.Group() {
+			appendAttr(buf, prefix, ga)
+		}
+
+		return
+	}
+
+	buf.WriteByte(' ')
+	buf.WriteString(prefix + a.Key)
+	buf.WriteByte('=')
+
+	value := a.Value.String()
+	if needsQuoting(value) {
+		value = strconv.Quote(value)
+	}
+
+	buf.WriteString(value)
+}
+
+// needsQuoting reports whether a value must be quoted to be read back.
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	return strings.ContainsFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"' || r == '=' || !unicode.IsPrint(r)
+	})
+}
@@ -0,0 +1,188 @@
+package matcher
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Options configures a Matcher. Every field accepts multiple values.
+// Contains, Prefixes and Globs are compared ignoring case; regular
+// expressions are used as-is, so add "(?i)" to make them case-insensitive.
+type Options struct {
+	Contains []string // names containing any of these substrings
+	Prefixes []string // names starting with any of these prefixes
+	Globs    []string // names matching any of these glob patterns, see path.Match
+	Regexes  []string // names matching any of these regular expressions
+
+	NotContains []string // exclude names containing any of these substrings
+	NotPrefixes []string // exclude names starting with any of these prefixes
+	NotGlobs    []string // exclude names matching any of these glob patterns
+	NotRegexes  []string // exclude names matching any of these regular expressions
+
+	// MatchAll requires a name to match every include rule, instead of any
+	// of them. Exclude rules always reject a name if any of them matches.
+	MatchAll bool
+}
+
+// rule is a single compiled name rule.
+type rule struct {
+	kind    string
+	pattern string
+	match   func(name string) bool
+}
+
+// String returns a description of the rule, like `glob "ci-*"`.
+func (r rule) String() string {
+	return fmt.Sprintf("%s %q", r.kind, r.pattern)
+}
+
+// Matcher decides whether a resource is selected based on its name. A nil
+// Matcher, or one without rules, selects every name.
+type Matcher struct {
+	include  []rule
+	exclude  []rule
+	matchAll bool
+}
+
+// New compiles the options into a Matcher. It returns an error if any glob
+// pattern or regular expression is invalid.
+func New(opts Options) (*Matcher, error) {
+	m := &Matcher{matchAll: opts.MatchAll}
+
+	var err error
+
+	if m.include, err = compile(opts.Contains, opts.Prefixes, opts.Globs, opts.Regexes); err != nil {
+		return nil, err
+	}
+
+	if m.exclude, err = compile(opts.NotContains, opts.NotPrefixes, opts.NotGlobs, opts.NotRegexes); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Contains is a shortcut to create a Matcher selecting names containing
+// the given substring, ignoring case. An empty substring selects every name.
+func Contains(substr string) *Matcher {
+	if substr == "" {
+		return nil
+	}
+
+	m, _ := New(Options{Contains: []string{substr}}) // contains rules never fail to compile
+	return m
+}
+
+// compile converts every kind of rule into a list of rules.
+func compile(contains, prefixes, globs, regexes []string) ([]rule, error) {
+	rules := make([]rule, 0, len(contains)+len(prefixes)+len(globs)+len(regexes))
+
+	for _, s := range contains {
+		lower := strings.ToLower(s)
+		rules = append(rules, rule{kind: "contains", pattern: s, match: func(name string) bool {
+			return strings.Contains(strings.ToLower(name), lower)
+		}})
+	}
+
+	for _, s := range prefixes {
+		lower := strings.ToLower(s)
+		rules = append(rules, rule{kind: "prefix", pattern: s, match: func(name string) bool {
+			return strings.HasPrefix(strings.ToLower(name), lower)
+		}})
+	}
+
+	for _, s := range globs {
+		lower := strings.ToLower(s)
+		if _, err := path.Match(lower, ""); err != nil {
+			return nil, fmt.Errorf("invalid name glob %q: %w", s, err)
+		}
+
+		rules = append(rules, rule{kind: "glob", pattern: s, match: func(name string) bool {
+			ok, _ := path.Match(lower, strings.ToLower(name))
+			return ok
+		}})
+	}
+
+	for _, s := range regexes {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name regex %q: %w", s, err)
+		}
+
+		rules = append(rules, rule{kind: "regex", pattern: s, match: re.MatchString})
+	}
+
+	return rules, nil
+}
+
+// Match checks if a name is selected. If it isn't, it also returns the
+// reason why, which can be used in log messages.
+func (m *Matcher) Match(name string) (bool, string) {
+	if m == nil {
+		return true, ""
+	}
+
+	for _, r := range m.exclude {
+		if r.match(name) {
+			return false, fmt.Sprintf("name matches excluded %s", r)
+		}
+	}
+
+	if len(m.include) == 0 {
+		return true, ""
+	}
+
+	for _, r := range m.include {
+		matched := r.match(name)
+
+		if m.matchAll && !matched {
+			return false, fmt.Sprintf("name does not match %s", r)
+		}
+
+		if !m.matchAll && matched {
+			return true, ""
+		}
+	}
+
+	if m.matchAll {
+		return true, ""
+	}
+
+	return false, "name does not match " + m.describe(m.include, " or ")
+}
+
+// String returns a description of every rule in the Matcher, or an empty
+// string if it has no rules.
+func (m *Matcher) String() string {
+	if m == nil {
+		return ""
+	}
+
+	sep := " or "
+	if m.matchAll {
+		sep = " and "
+	}
+
+	var parts []string
+	if len(m.include) > 0 {
+		parts = append(parts, "name matching "+m.describe(m.include, sep))
+	}
+
+	if len(m.exclude) > 0 {
+		parts = append(parts, "name not matching "+m.describe(m.exclude, " or "))
+	}
+
+	return strings.Join(parts, " and ")
+}
+
+// describe joins the descriptions of the given rules with a separator.
+func (m *Matcher) describe(rules []rule, sep string) string {
+	descriptions := make([]string, 0, len(rules))
+	for _, r := range rules {
+		descriptions = append(descriptions, r.String())
+	}
+
+	return strings.Join(descriptions, sep)
+}
@@ -0,0 +1,104 @@
+package matcher
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  Options
+		input string
+		want  bool
+	}{
+		{name: "no rules selects everything", opts: Options{}, input: "anything", want: true},
+		{name: "contains ignores case", opts: Options{Contains: []string{"K3S"}}, input: "ci-1-k3s", want: true},
+		{name: "contains no match", opts: Options{Contains: []string{"gke"}}, input: "ci-1-k3s", want: false},
+		{name: "prefix", opts: Options{Prefixes: []string{"ci-"}}, input: "ci-1-k3s", want: true},
+		{name: "prefix no match", opts: Options{Prefixes: []string{"ci-"}}, input: "prod-ci-1", want: false},
+		{name: "glob", opts: Options{Globs: []string{"ci-*-k3s"}}, input: "CI-42-k3s", want: true},
+		{name: "regex", opts: Options{Regexes: []string{`^ci-\d+-`}}, input: "ci-42-k3s", want: true},
+		{name: "regex no match", opts: Options{Regexes: []string{`^ci-\d+-`}}, input: "ci-abc-k3s", want: false},
+		{name: "any of multiple values", opts: Options{Prefixes: []string{"dev-", "ci-"}}, input: "ci-1", want: true},
+		{
+			name:  "negation wins over inclusion",
+			opts:  Options{Regexes: []string{`^ci-\d+-`}, NotGlobs: []string{"ci-*-keep"}},
+			input: "ci-42-keep",
+			want:  false,
+		},
+		{
+			name:  "negation alone",
+			opts:  Options{NotPrefixes: []string{"prod-"}},
+			input: "dev-cluster",
+			want:  true,
+		},
+		{name: "not contains", opts: Options{NotContains: []string{"KEEP"}}, input: "ci-keep", want: false},
+		{name: "not regex", opts: Options{NotRegexes: []string{`keep$`}}, input: "ci-keep", want: false},
+		{
+			name:  "match all requires every rule",
+			opts:  Options{Prefixes: []string{"ci-"}, Contains: []string{"k3s"}, MatchAll: true},
+			input: "ci-1-gke",
+			want:  false,
+		},
+		{
+			name:  "match all with every rule matching",
+			opts:  Options{Prefixes: []string{"ci-"}, Contains: []string{"k3s"}, MatchAll: true},
+			input: "ci-1-k3s",
+			want:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := New(tc.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, reason := m.Match(tc.input)
+			if got != tc.want {
+				t.Fatalf("expecting match to be %v, got %v (reason: %q)", tc.want, got, reason)
+			}
+
+			if !got && reason == "" {
+				t.Fatalf("expecting a reason when the name isn't selected")
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Options{Regexes: []string{"("}}); err == nil {
+		t.Fatalf("expecting an error for an invalid regex")
+	}
+
+	if _, err := New(Options{NotGlobs: []string{"["}}); err == nil {
+		t.Fatalf("expecting an error for an invalid glob")
+	}
+}
+
+func TestNilMatcher(t *testing.T) {
+	var m *Matcher
+
+	if ok, _ := m.Match("anything"); !ok {
+		t.Fatalf("expecting a nil matcher to select everything")
+	}
+
+	if m.String() != "" {
+		t.Fatalf("expecting a nil matcher to have no description")
+	}
+
+	if Contains("") != nil {
+		t.Fatalf("expecting an empty substring to create a nil matcher")
+	}
+}
+
+func TestString(t *testing.T) {
+	m, err := New(Options{Prefixes: []string{"ci-"}, NotGlobs: []string{"*-keep"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `name matching prefix "ci-" and name not matching glob "*-keep"`
+	if got := m.String(); got != want {
+		t.Fatalf("expecting %q, got %q", want, got)
+	}
+}
@@ -2,13 +2,35 @@ package outputwriter
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sync"
 )
 
+var (
+	mu     sync.Mutex             // ensures lines written concurrently are never interleaved
+	stdout io.Writer  = os.Stdout // where WriteStdoutf writes to
+)
+
+// SetStdout changes where WriteStdoutf writes to, so stdout can be kept
+// clean when it's used for structured output.
+func SetStdout(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	stdout = w
+}
+
 func WriteStdoutf(format string, args ...interface{}) {
-	fmt.Fprintf(os.Stdout, format+"\n", args...)
+	mu.Lock()
+	defer mu.Unlock()
+
+	fmt.Fprintf(stdout, format+"\n", args...)
 }
 
 func WriteStderrf(format string, args ...interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+
 	fmt.Fprintf(os.Stderr, format+"\n", args...)
 }
@@ -0,0 +1,359 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Screen is the size of the terminal a checklist is drawn on. A zero width
+// or height is unlimited.
+type Screen struct {
+	Width  int
+	Height int
+}
+
+// key is a key pressed by the operator, as far as the checklist cares.
+type key int
+
+const (
+	keyOther    key = iota // any key the checklist ignores
+	keyUp                  // the up arrow, or "k"
+	keyDown                // the down arrow, or "j"
+	keyPageUp              // the page up key
+	keyPageDown            // the page down key
+	keyToggle              // the space bar
+	keyAll                 // "a", selecting every item
+	keyNone                // "n", deselecting every item
+	keyEnter               // the enter key, confirming the selection
+	keyYes                 // "y", answering yes to the confirmation
+	keyCancel              // "q", escape, ctrl-c or ctrl-d
+)
+
+// keyReader decodes the keys pressed on a terminal in raw mode.
+type keyReader struct {
+	r   io.Reader
+	buf []byte
+}
+
+// next returns the next key pressed. Escape sequences are expected to be
+// read at once, so an escape at the end of what was read is a lone escape.
+func (k *keyReader) next() (key, error) {
+	chunk := make([]byte, 64)
+	for len(k.buf) == 0 {
+		n, err := k.r.Read(chunk)
+		k.buf = append(k.buf, chunk[:n]...)
+
+		if n == 0 && err != nil {
+			return keyOther, err //nolint:wrapcheck // the caller wraps read errors
+		}
+	}
+
+	b := k.buf[0]
+	k.buf = k.buf[1:]
+
+	switch b {
+	case 0x1b:
+		return k.escape(), nil
+	case ' ':
+		return keyToggle, nil
+	case '\r', '\n':
+		return keyEnter, nil
+	case 'k':
+		return keyUp, nil
+	case 'j':
+		return keyDown, nil
+	case 'a', 'A':
+		return keyAll, nil
+	case 'n', 'N':
+		return keyNone, nil
+	case 'y', 'Y':
+		return keyYes, nil
+	case 'q', 'Q', 0x03, 0x04:
+		return keyCancel, nil
+	default:
+		return keyOther, nil
+	}
+}
+
+// escape decodes the rest of an escape sequence, like "\x1b[A" for the up
+// arrow, once its escape has been read.
+func (k *keyReader) escape() key {
+	if len(k.buf) == 0 {
+		return keyCancel
+	}
+
+	if k.buf[0] != '[' && k.buf[0] != 'O' {
+		return keyOther
+	}
+
+	// parameter bytes come before the final byte naming the key
+	i := 1
+	for i < len(k.buf) && k.buf[i] >= 0x30 && k.buf[i] <= 0x3f {
+		i++
+	}
+
+	if i == len(k.buf) {
+		k.buf = nil
+		return keyOther
+	}
+
+	params, final := string(k.buf[1:i]), k.buf[i]
+	k.buf = k.buf[i+1:]
+
+	switch {
+	case final == 'A':
+		return keyUp
+	case final == 'B':
+		return keyDown
+	case final == '~' && params == "5":
+		return keyPageUp
+	case final == '~' && params == "6":
+		return keyPageDown
+	default:
+		return keyOther
+	}
+}
+
+// row is a line of the checklist: either the header of a group, or the
+// item at position pos in the display order.
+type row struct {
+	group string
+	pos   int
+}
+
+// checklist is the state of a checklist drawn on a terminal.
+type checklist struct {
+	s      *selection
+	order  []int  // the item indices, in display order
+	rows   []row  // the group headers and items, in display order
+	cursor int    // the position in the display order of the item under the cursor
+	offset int    // the first row shown, when they don't all fit
+	screen Screen // the size of the terminal
+	drawn  int    // the number of lines drawn last time
+	status string // the message shown under the items
+}
+
+// newChecklist creates the state of a checklist of the given items, every
+// one of them selected.
+func newChecklist(items []Item, screen Screen) *checklist {
+	c := &checklist{s: newSelection(items), order: displayOrder(items), screen: screen}
+
+	for pos, i := range c.order {
+		if pos == 0 || items[i].Group != items[c.order[pos-1]].Group {
+			c.rows = append(c.rows, row{group: items[i].Group, pos: -1})
+		}
+
+		c.rows = append(c.rows, row{pos: pos})
+	}
+
+	return c
+}
+
+// Checklist draws the items grouped by group, every one of them selected,
+// and lets the operator move between them with the arrow keys and tick or
+// untick them with the space bar, until they confirm the selection with
+// enter. Ticking an item ticks the items blocking it, and unticking an item
+// unticks the items it blocks. It reads keys from in, which is expected to
+// be a terminal in raw mode, and draws on out. It returns the indices of the
+// selected items, in order, or no indices if the operator cancels.
+func Checklist(in io.Reader, out io.Writer, screen Screen, items []Item) ([]int, error) {
+	c := newChecklist(items, screen)
+	keys := &keyReader{r: in}
+
+	for {
+		c.draw(out, "")
+
+		k, err := keys.next()
+		if err != nil {
+			return nil, keyError(err)
+		}
+
+		switch k {
+		case keyUp:
+			c.move(-1)
+		case keyDown:
+			c.move(1)
+		case keyPageUp:
+			c.move(-c.pageSize())
+		case keyPageDown:
+			c.move(c.pageSize())
+		case keyToggle:
+			i := c.order[c.cursor]
+			c.report(c.s.set(i, !c.s.selected[i]))
+		case keyAll:
+			c.setAll(true)
+		case keyNone:
+			c.setAll(false)
+		case keyEnter:
+			indices := c.s.indices()
+			if len(indices) == 0 {
+				c.status = "no resources selected: tick some, or press q to cancel"
+				continue
+			}
+
+			c.draw(out, fmt.Sprintf("delete %d resources? [y/N]", len(indices)))
+
+			answer, err := keys.next()
+			if err != nil {
+				return nil, keyError(err)
+			}
+
+			if answer == keyYes {
+				return indices, nil
+			}
+
+			c.status = ""
+		case keyCancel:
+			return nil, nil
+		case keyYes, keyOther:
+		}
+	}
+}
+
+// keyError returns the error that stopped reading keys, or nil if the input
+// ended.
+func keyError(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return fmt.Errorf("unable to read key: %w", err)
+}
+
+// move moves the cursor by delta items, staying within the list.
+func (c *checklist) move(delta int) {
+	c.cursor = min(max(c.cursor+delta, 0), len(c.order)-1)
+	c.status = ""
+}
+
+// setAll selects or deselects every item, one at a time, so the items they
+// imply follow.
+func (c *checklist) setAll(selected bool) {
+	for _, i := range c.order {
+		c.s.set(i, selected)
+	}
+
+	c.status = fmt.Sprintf("%s every resource", verb(selected))
+}
+
+// report sets the status to the items that changed along with the one
+// toggled.
+func (c *checklist) report(implied []int) {
+	if len(implied) == 0 {
+		c.status = ""
+		return
+	}
+
+	labels := make([]string, 0, len(implied))
+	for _, j := range implied {
+		labels = append(labels, c.s.items[j].Group+" "+c.s.items[j].Label)
+	}
+
+	c.status = fmt.Sprintf("also %s %s", verb(c.s.selected[implied[0]]), strings.Join(labels, ", "))
+}
+
+// pageSize returns the number of rows shown at once, or every row if they
+// all fit.
+func (c *checklist) pageSize() int {
+	if c.screen.Height <= 0 {
+		return len(c.rows)
+	}
+
+	// the title and the status take a line each
+	return max(c.screen.Height-2, 1)
+}
+
+// scroll moves the rows shown so the cursor is one of them, along with its
+// group header when it's the first item of its group.
+func (c *checklist) scroll() {
+	current := 0
+	for n, r := range c.rows {
+		if r.pos == c.cursor {
+			current = n
+			break
+		}
+	}
+
+	first := current
+	if first > 0 && c.rows[first-1].pos < 0 {
+		first--
+	}
+
+	size := c.pageSize()
+	if first < c.offset {
+		c.offset = first
+	}
+
+	if current >= c.offset+size {
+		c.offset = current - size + 1
+	}
+}
+
+// draw draws the checklist over the one drawn last time, with the given
+// question in place of the status if it's set.
+func (c *checklist) draw(out io.Writer, question string) {
+	c.scroll()
+
+	end := min(c.offset+c.pageSize(), len(c.rows))
+
+	lines := make([]string, 0, end-c.offset+2)
+	lines = append(lines, fmt.Sprintf("%d of %d resources selected: up and down to move, space to toggle, a for all, n for none, enter to delete, q to cancel", len(c.s.indices()), len(c.order)))
+
+	for _, r := range c.rows[c.offset:end] {
+		if r.pos < 0 {
+			lines = append(lines, r.group)
+			continue
+		}
+
+		cursor, mark := " ", " "
+		if r.pos == c.cursor {
+			cursor = ">"
+		}
+
+		i := c.order[r.pos]
+		if c.s.selected[i] {
+			mark = "x"
+		}
+
+		lines = append(lines, fmt.Sprintf("%s [%s] %s", cursor, mark, c.s.items[i].Label))
+	}
+
+	status := c.status
+	if question != "" {
+		status = question
+	}
+
+	lines = append(lines, status)
+
+	var b strings.Builder
+
+	// go back to the first line drawn last time, and clear everything
+	// below it
+	if c.drawn > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", c.drawn)
+	}
+
+	b.WriteString("\r\x1b[J")
+
+	for _, line := range lines {
+		b.WriteString(c.truncate(line))
+		b.WriteString("\r\n")
+	}
+
+	c.drawn = len(lines)
+	fmt.Fprint(out, b.String())
+}
+
+// truncate shortens a line so it fits on a single line of the screen,
+// since wrapped lines would throw off the count of lines drawn.
+func (c *checklist) truncate(line string) string {
+	if c.screen.Width <= 0 || utf8.RuneCountInString(line) < c.screen.Width {
+		return line
+	}
+
+	runes := []rune(line)
+	return string(runes[:c.screen.Width-1])
+}
@@ -0,0 +1,183 @@
+// Package prompt asks an operator, on a terminal or through plain lines of
+// input, which resources to delete.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Item is a resource offered for selection.
+type Item struct {
+	Group  string // items are listed under their group, like the resource type
+	Label  string // the description of the item, like its name and ID
+	Blocks []int  // the indices of the items that can't be selected unless this one is
+}
+
+// selection tracks which items are selected, keeping the selection
+// consistent with the dependencies between items: an item can only be
+// selected if every item blocking it is selected too.
+type selection struct {
+	items    []Item
+	selected []bool
+}
+
+// newSelection creates a selection with every item selected.
+func newSelection(items []Item) *selection {
+	s := &selection{items: items, selected: make([]bool, len(items))}
+	for i := range s.selected {
+		s.selected[i] = true
+	}
+
+	return s
+}
+
+// set selects or deselects an item. Deselecting an item also deselects the
+// items it blocks, and selecting an item also selects the items blocking
+// it. It returns the indices of the other items that changed as a result.
+func (s *selection) set(i int, selected bool) []int {
+	var implied []int
+
+	var visit func(int)
+	visit = func(j int) {
+		if s.selected[j] == selected {
+			return
+		}
+
+		s.selected[j] = selected
+		if j != i {
+			implied = append(implied, j)
+		}
+
+		for _, k := range s.related(j, selected) {
+			visit(k)
+		}
+	}
+
+	s.selected[i] = !selected
+	visit(i)
+
+	sort.Ints(implied)
+	return implied
+}
+
+// related returns the items whose selection follows the selection of item
+// i: the items blocking it when it's selected, and the items it blocks when
+// it's deselected.
+func (s *selection) related(i int, selected bool) []int {
+	if !selected {
+		return s.items[i].Blocks
+	}
+
+	var blockers []int
+	for j, item := range s.items {
+		for _, blocked := range item.Blocks {
+			if blocked == i {
+				blockers = append(blockers, j)
+			}
+		}
+	}
+
+	return blockers
+}
+
+// indices returns the indices of the selected items, in order.
+func (s *selection) indices() []int {
+	indices := make([]int, 0, len(s.selected))
+	for i, selected := range s.selected {
+		if selected {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// displayOrder returns the indices of the items grouped by group, in the
+// order each group first appears, and in their original order within a
+// group.
+func displayOrder(items []Item) []int {
+	groups := make(map[string]int)
+	for _, item := range items {
+		if _, ok := groups[item.Group]; !ok {
+			groups[item.Group] = len(groups)
+		}
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return groups[items[order[a]].Group] < groups[items[order[b]].Group]
+	})
+
+	return order
+}
+
+// Confirm asks the operator, item by item in order, whether to select it.
+// Items blocked by a declined item are declined without asking. It returns
+// the indices of the selected items, in order. If the input ends, the
+// remaining items are declined.
+func Confirm(in io.Reader, out io.Writer, items []Item) ([]int, error) {
+	s := newSelection(items)
+	scanner := bufio.NewScanner(in)
+
+	for i, item := range items {
+		if !s.selected[i] {
+			fmt.Fprintf(out, "skipping %s %s: it depends on a resource that is kept\n", item.Group, item.Label)
+			continue
+		}
+
+		ok, err := ask(scanner, out, fmt.Sprintf("delete %s %s? [y/N]: ", item.Group, item.Label))
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			s.set(i, false)
+		}
+	}
+
+	return s.indices(), nil
+}
+
+// ask writes a question and reads a yes or no answer. Anything but "y" or
+// "yes" is a no, and so is the end of the input.
+func ask(scanner *bufio.Scanner, out io.Writer, question string) (bool, error) {
+	fmt.Fprint(out, question)
+
+	if !scanner.Scan() {
+		return false, readError(scanner)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// readError returns the error that stopped the scanner, or nil if the
+// input ended.
+func readError(scanner *bufio.Scanner) error {
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read answer: %w", err)
+	}
+
+	return nil
+}
+
+// verb describes a selection change.
+func verb(selected bool) string {
+	if selected {
+		return "selected"
+	}
+
+	return "deselected"
+}
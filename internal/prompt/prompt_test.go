@@ -0,0 +1,165 @@
+package prompt
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testItems returns an instance blocking a network, and an unrelated volume.
+func testItems() []Item {
+	return []Item{
+		{Group: "instance", Label: `"web" (ID: "i-1")`, Blocks: []int{2}},
+		{Group: "volume", Label: `"data" (ID: "v-1")`},
+		{Group: "network", Label: `"net" (ID: "n-1")`},
+	}
+}
+
+// frame returns the lines of the nth checklist drawn in out, counting
+// from the end if n is negative.
+func frame(out string, n int) []string {
+	frames := strings.Split(out, "\r\x1b[J")[1:]
+	if n < 0 {
+		n += len(frames)
+	}
+
+	// each frame but the last ends with the move back to its first line
+	drawn, _, _ := strings.Cut(frames[n], "\x1b[")
+	return strings.Split(strings.TrimSuffix(drawn, "\r\n"), "\r\n")
+}
+
+func TestChecklist(t *testing.T) {
+	const (
+		up    = "\x1b[A"
+		down  = "\x1b[B"
+		enter = "\r"
+	)
+
+	tests := []struct {
+		name  string
+		input string
+		want  []int
+	}{
+		{name: "confirm everything", input: enter + "y", want: []int{0, 1, 2}},
+		{name: "toggle the volume off", input: down + " " + enter + "y", want: []int{0, 2}},
+		{name: "j and k move too", input: "jjk " + enter + "y", want: []int{0, 2}},
+		{name: "the cursor stays within the list", input: up + " " + enter + "y", want: []int{1}},
+		{name: "keeping the instance keeps its network", input: " " + enter + "y", want: []int{1}},
+		{name: "selecting the network selects its instance", input: "n" + down + down + " " + enter + "y", want: []int{0, 2}},
+		{name: "selecting all after none", input: "na" + enter + "y", want: []int{0, 1, 2}},
+		{name: "declined confirmation goes back to the list", input: enter + "n" + down + " " + enter + "y", want: []int{0, 2}},
+		{name: "nothing selected can't be confirmed", input: "n" + enter + "y", want: nil},
+		{name: "cancel", input: "q", want: nil},
+		{name: "escape", input: "\x1b", want: nil},
+		{name: "ctrl-c", input: "\x03", want: nil},
+		{name: "end of input", input: "", want: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := Checklist(strings.NewReader(tc.input), &out, Screen{}, testItems())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expecting selection %v, got %v, output:\n%q", tc.want, got, out.String())
+			}
+		})
+	}
+
+	t.Run("lists items grouped by group", func(t *testing.T) {
+		items := []Item{{Group: "instance", Label: "a"}, {Group: "volume", Label: "b"}, {Group: "instance", Label: "c"}}
+
+		var out bytes.Buffer
+		got, err := Checklist(strings.NewReader(down+" "+enter+"y"), &out, Screen{}, items)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// The second item listed is the second instance, before the volume.
+		if !reflect.DeepEqual(got, []int{0, 1}) {
+			t.Fatalf("expecting selection [0 1], got %v, output:\n%q", got, out.String())
+		}
+
+		want := []string{"instance", "> [x] a", "  [x] c", "volume", "  [x] b"}
+		if first := frame(out.String(), 0); !reflect.DeepEqual(first[1:6], want) {
+			t.Fatalf("expecting the checklist to start with %q, got %q", want, first)
+		}
+	})
+
+	t.Run("toggling reports the implied changes", func(t *testing.T) {
+		var out bytes.Buffer
+		if _, err := Checklist(strings.NewReader(" q"), &out, Screen{}, testItems()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		last := frame(out.String(), -1)
+		if status := last[len(last)-1]; status != `also deselected network "net" (ID: "n-1")` {
+			t.Fatalf("unexpected status %q", status)
+		}
+	})
+
+	t.Run("scrolls to keep the cursor on the screen", func(t *testing.T) {
+		items := make([]Item, 5)
+		for i := range items {
+			items[i] = Item{Group: "volume", Label: strconv.Itoa(i)}
+		}
+
+		var out bytes.Buffer
+		if _, err := Checklist(strings.NewReader(down+"\x1b[6~q"), &out, Screen{Height: 4}, items); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Two rows fit between the title and the status.
+		last := frame(out.String(), -1)
+		if want := []string{"  [x] 2", "> [x] 3"}; len(last) != 4 || !reflect.DeepEqual(last[1:3], want) {
+			t.Fatalf("expecting rows %q, got %q", want, last)
+		}
+	})
+
+	t.Run("truncates lines to the screen width", func(t *testing.T) {
+		var out bytes.Buffer
+		if _, err := Checklist(strings.NewReader("q"), &out, Screen{Width: 10}, testItems()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, line := range frame(out.String(), -1) {
+			if len(line) >= 10 {
+				t.Fatalf("expecting lines shorter than the screen, got %q", line)
+			}
+		}
+	})
+}
+
+func TestConfirm(t *testing.T) {
+	t.Run("declining a resource declines what depends on it", func(t *testing.T) {
+		var out bytes.Buffer
+		got, err := Confirm(strings.NewReader("n\ny\n"), &out, testItems())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, []int{1}) {
+			t.Fatalf("expecting selection [1], got %v", got)
+		}
+
+		if strings.Count(out.String(), "[y/N]") != 2 || !strings.Contains(out.String(), `skipping network "net"`) {
+			t.Fatalf("expecting the network to be skipped without asking, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("end of input declines the rest", func(t *testing.T) {
+		got, err := Confirm(strings.NewReader("yes\n"), &bytes.Buffer{}, testItems())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(got, []int{0}) {
+			t.Fatalf("expecting selection [0], got %v", got)
+		}
+	})
+}
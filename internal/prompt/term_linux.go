@@ -0,0 +1,9 @@
+package prompt
+
+import "golang.org/x/sys/unix"
+
+// The requests reading and setting the terminal mode.
+const (
+	ioctlGetTermios = unix.TCGETS
+	ioctlSetTermios = unix.TCSETS
+)
@@ -0,0 +1,14 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package prompt
+
+import (
+	"errors"
+	"os"
+)
+
+// MakeRaw returns an error, since raw mode isn't supported on this
+// platform.
+func MakeRaw(_ *os.File) (Screen, func() error, error) {
+	return Screen{}, nil, errors.New("raw terminal mode is not supported on this platform")
+}
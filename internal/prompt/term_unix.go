@@ -0,0 +1,52 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package prompt
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// MakeRaw puts the terminal f in raw mode, so keys are read as soon as
+// they're pressed, without being echoed. It returns the size of the
+// terminal, and a function restoring the mode it was in.
+func MakeRaw(f *os.File) (Screen, func() error, error) {
+	fd := int(f.Fd()) //nolint:gosec // file descriptors fit in an int
+
+	previous, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return Screen{}, nil, fmt.Errorf("unable to read the terminal mode: %w", err)
+	}
+
+	// the same settings as cfmakeraw(3)
+	raw := *previous
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		return Screen{}, nil, fmt.Errorf("unable to put the terminal in raw mode: %w", err)
+	}
+
+	restore := func() error {
+		if err := unix.IoctlSetTermios(fd, ioctlSetTermios, previous); err != nil {
+			return fmt.Errorf("unable to restore the terminal mode: %w", err)
+		}
+
+		return nil
+	}
+
+	// an unknown size is unlimited
+	var screen Screen
+	if size, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ); err == nil {
+		screen = Screen{Width: int(size.Col), Height: int(size.Row)}
+	}
+
+	return screen, restore, nil
+}
@@ -0,0 +1,172 @@
+package protect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Rules is a list of resources that dropkick must never delete. A resource
+// is protected if it matches any of the rules.
+type Rules struct {
+	// IDs protects resources with any of these IDs.
+	IDs []string `yaml:"ids,omitempty"`
+
+	// NameGlobs protects resources whose name matches any of these glob
+	// patterns, ignoring case. See path.Match for the syntax.
+	NameGlobs []string `yaml:"name_globs,omitempty"`
+
+	// NameRegexes protects resources whose name matches any of these
+	// regular expressions.
+	NameRegexes []string `yaml:"name_regexes,omitempty"`
+
+	// Types protects every resource of any of these types. Types are
+	// compared ignoring case, spaces, dashes, underscores and a trailing
+	// "s", so "SSH keys", "ssh-key" and "sshkeys" are all the same type.
+	Types []string `yaml:"types,omitempty"`
+
+	regexes []*regexp.Regexp
+}
+
+// Load reads the rules from a YAML file.
+func Load(filename string) (*Rules, error) {
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read protect file %q: %w", filename, err)
+	}
+
+	rules, err := Parse(bytes.NewReader(contents))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse protect file %q: %w", filename, err)
+	}
+
+	return rules, nil
+}
+
+// Parse reads the rules from YAML. It returns an error if any of the glob
+// patterns or regular expressions is invalid, or if unknown fields are found.
+func Parse(r io.Reader) (*Rules, error) {
+	var rules Rules
+
+	dec := yaml.NewDecoder(r)
+	dec.SetStrict(true)
+
+	if err := dec.Decode(&rules); err != nil && err != io.EOF { //nolint:errorlint // the decoder returns io.EOF unwrapped
+		return nil, fmt.Errorf("unable to decode rules: %w", err)
+	}
+
+	return &rules, nil
+}
+
+// UnmarshalYAML decodes the rules, by implementing the yaml.Unmarshaler
+// interface, so rules can also be embedded in other YAML documents. It
+// returns an error if any of the glob patterns or regular expressions is
+// invalid.
+func (r *Rules) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Rules
+
+	var decoded plain
+	if err := unmarshal(&decoded); err != nil {
+		return err
+	}
+
+	rules := Rules(decoded)
+	rules.regexes = nil
+
+	for _, glob := range rules.NameGlobs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid name glob %q: %w", glob, err)
+		}
+	}
+
+	for _, expr := range rules.NameRegexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid name regex %q: %w", expr, err)
+		}
+
+		rules.regexes = append(rules.regexes, re)
+	}
+
+	*r = rules
+	return nil
+}
+
+// Merge combines rules into a single set of rules protecting every resource
+// protected by any of them. Nil rules are ignored, and nil is returned if
+// every rule is nil.
+func Merge(rules ...*Rules) *Rules {
+	var merged *Rules
+
+	for _, r := range rules {
+		if r == nil {
+			continue
+		}
+
+		if merged == nil {
+			merged = &Rules{}
+		}
+
+		merged.IDs = append(merged.IDs, r.IDs...)
+		merged.NameGlobs = append(merged.NameGlobs, r.NameGlobs...)
+		merged.NameRegexes = append(merged.NameRegexes, r.NameRegexes...)
+		merged.Types = append(merged.Types, r.Types...)
+		merged.regexes = append(merged.regexes, r.regexes...)
+	}
+
+	return merged
+}
+
+// Match checks if a resource is protected by any of the rules. If it is, it
+// returns a description of the first rule that matched. It's safe to call
+// on nil rules, which protect nothing.
+func (r *Rules) Match(resourceType, id, name string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+
+	if slices.ContainsFunc(r.Types, func(t string) bool { return NormalizeType(t) == NormalizeType(resourceType) }) {
+		return fmt.Sprintf("type %q", resourceType), true
+	}
+
+	if id != "" && slices.Contains(r.IDs, id) {
+		return fmt.Sprintf("ID %q", id), true
+	}
+
+	for _, glob := range r.NameGlobs {
+		if ok, _ := path.Match(strings.ToLower(glob), strings.ToLower(name)); ok {
+			return fmt.Sprintf("name glob %q", glob), true
+		}
+	}
+
+	for _, re := range r.regexes {
+		if re.MatchString(name) {
+			return fmt.Sprintf("name regex %q", re.String()), true
+		}
+	}
+
+	return "", false
+}
+
+// NormalizeType converts a resource type into a canonical form, so it can be
+// compared with how users write it: it keeps only lowercase letters and
+// digits, and removes a trailing "s".
+func NormalizeType(resourceType string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(resourceType) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "s")
+}
@@ -0,0 +1,134 @@
+package protect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		rules, err := Parse(strings.NewReader(`
+ids:
+  - abc-123
+name_globs:
+  - "prod-*"
+name_regexes:
+  - "^keep-\\d+$"
+types:
+  - SSH keys
+`))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(rules.IDs) != 1 || len(rules.NameGlobs) != 1 || len(rules.NameRegexes) != 1 || len(rules.Types) != 1 {
+			t.Fatalf("expecting one rule of each kind, got %+v", rules)
+		}
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		rules, err := Parse(strings.NewReader(""))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, ok := rules.Match("instance", "1", "test"); ok {
+			t.Fatalf("expecting empty rules to protect nothing")
+		}
+	})
+
+	cases := map[string]string{
+		"invalid regex": "name_regexes: ['(']",
+		"invalid glob":  "name_globs: ['[']",
+		"unknown field": "names: ['test']",
+	}
+
+	for name, contents := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(contents)); err == nil {
+				t.Fatalf("expecting an error, got nil")
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	rules, err := Parse(strings.NewReader(`
+ids: [abc-123]
+name_globs: ["prod-*"]
+name_regexes: ["^keep-\\d+$"]
+types: [ssh-keys]
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name         string
+		resourceType string
+		id           string
+		resName      string
+		wantRule     string
+		wantMatch    bool
+	}{
+		{name: "by id", resourceType: "instance", id: "abc-123", resName: "test", wantRule: `ID "abc-123"`, wantMatch: true},
+		{name: "by glob ignoring case", resourceType: "instance", id: "1", resName: "PROD-db", wantRule: `name glob "prod-*"`, wantMatch: true},
+		{name: "by regex", resourceType: "volume", id: "1", resName: "keep-42", wantRule: `name regex "^keep-\\d+$"`, wantMatch: true},
+		{name: "by type", resourceType: "ssh key", id: "1", resName: "test", wantRule: `type "ssh key"`, wantMatch: true},
+		{name: "no match", resourceType: "instance", id: "1", resName: "keep-me", wantMatch: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, ok := rules.Match(tc.resourceType, tc.id, tc.resName)
+			if ok != tc.wantMatch {
+				t.Fatalf("expecting match to be %v, got %v", tc.wantMatch, ok)
+			}
+
+			if rule != tc.wantRule {
+				t.Fatalf("expecting rule %q, got %q", tc.wantRule, rule)
+			}
+		})
+	}
+
+	t.Run("nil rules protect nothing", func(t *testing.T) {
+		var rules *Rules
+		if _, ok := rules.Match("instance", "abc-123", "prod-db"); ok {
+			t.Fatalf("expecting nil rules to protect nothing")
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	if Merge(nil, nil) != nil {
+		t.Fatalf("expecting nil when merging only nil rules")
+	}
+
+	ids, err := Parse(strings.NewReader("ids: [abc-123]"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	regexes, err := Parse(strings.NewReader(`name_regexes: ["^keep-"]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	merged := Merge(ids, nil, regexes)
+
+	if _, ok := merged.Match("instance", "abc-123", "test"); !ok {
+		t.Fatalf("expecting the merged rules to protect by ID")
+	}
+
+	if _, ok := merged.Match("instance", "1", "keep-me"); !ok {
+		t.Fatalf("expecting the merged rules to protect by name regex")
+	}
+}
+
+func TestNormalizeType(t *testing.T) {
+	for _, in := range []string{"SSH keys", "ssh-key", "sshkeys", "ssh_key"} {
+		if got := NormalizeType(in); got != "sshkey" {
+			t.Fatalf("expecting %q to normalize to %q, got %q", in, "sshkey", got)
+		}
+	}
+}
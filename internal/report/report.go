@@ -0,0 +1,275 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Action is what dropkick did with a resource it found.
+type Action string
+
+const (
+	ActionSkipped   Action = "skipped"   // the resource was not selected, see the reason
+	ActionProtected Action = "protected" // the resource matched a protect rule, see the reason
+	ActionRefused   Action = "refused"   // the resource was selected, but nuke is not enabled
+	ActionPlanned   Action = "planned"   // the resource was recorded into a plan
+	ActionDeleted   Action = "deleted"   // the resource was deleted
+	ActionPending   Action = "pending"   // the resource was deleted, but was still present when the wait timed out, see the reason
+	ActionFailed    Action = "failed"    // the resource failed to be deleted, see the reason
+)
+
+// Record describes a single resource found by dropkick and what was done
+// with it.
+type Record struct {
+	Provider string `json:"provider" yaml:"provider"`
+	Region   string `json:"region" yaml:"region"`
+	Type     string `json:"type" yaml:"type"`
+	ID       string `json:"id" yaml:"id"`
+	Name     string `json:"name" yaml:"name"`
+	Selected bool   `json:"selected" yaml:"selected"`
+	Action   Action `json:"action" yaml:"action"`
+	Reason   string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Summary counts what was done with the resources of a single type.
+type Summary struct {
+	Type      string `json:"type" yaml:"type"`
+	Found     int    `json:"found" yaml:"found"`
+	Filtered  int    `json:"filtered" yaml:"filtered"`
+	Protected int    `json:"protected" yaml:"protected"`
+	Refused   int    `json:"refused" yaml:"refused"`
+	Deleted   int    `json:"deleted" yaml:"deleted"`
+	Pending   int    `json:"pending" yaml:"pending"`
+	Failed    int    `json:"failed" yaml:"failed"`
+}
+
+// add counts a record in the summary.
+func (s *Summary) add(record Record) {
+	s.Found++
+
+	switch record.Action {
+	case ActionSkipped:
+		s.Filtered++
+	case ActionProtected:
+		s.Protected++
+	case ActionRefused:
+		s.Refused++
+	case ActionDeleted:
+		s.Deleted++
+	case ActionPending:
+		s.Pending++
+	case ActionFailed:
+		s.Failed++
+	case ActionPlanned:
+		// Planned resources are only counted as found.
+	}
+}
+
+// columns returns the type and counters of a summary in the same order as
+// the summary header.
+func (s Summary) columns() []string {
+	counters := []int{s.Found, s.Filtered, s.Protected, s.Refused, s.Deleted, s.Pending, s.Failed}
+
+	columns := make([]string, 0, len(counters)+1)
+	columns = append(columns, s.Type)
+
+	for _, n := range counters {
+		columns = append(columns, strconv.Itoa(n))
+	}
+
+	return columns
+}
+
+// Summarize counts the records per resource type, in the order the types
+// were first found, followed by a summary of every type named "total".
+func Summarize(records []Record) []Summary {
+	var (
+		summaries []Summary
+		index     = make(map[string]int)
+		total     = Summary{Type: "total"}
+	)
+
+	for _, record := range records {
+		i, ok := index[record.Type]
+		if !ok {
+			i = len(summaries)
+			index[record.Type] = i
+			summaries = append(summaries, Summary{Type: record.Type})
+		}
+
+		summaries[i].add(record)
+		total.add(record)
+	}
+
+	return append(summaries, total)
+}
+
+// Report is the structured output of a dropkick run.
+type Report struct {
+	Resources []Record  `json:"resources" yaml:"resources"`
+	Summary   []Summary `json:"summary" yaml:"summary"`
+}
+
+// Recorder collects records from concurrent workers. A nil Recorder
+// discards every record.
+type Recorder struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{records: make([]Record, 0)}
+}
+
+// Add adds a record to the Recorder.
+func (r *Recorder) Add(record Record) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records = append(r.records, record)
+}
+
+// Report returns a Report with every record added so far, and their
+// summary.
+func (r *Recorder) Report() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := append([]Record(nil), r.records...)
+	return &Report{Resources: records, Summary: Summarize(records)}
+}
+
+// Format is a structured output format.
+type Format string
+
+const (
+	FormatText  Format = "text"  // no structured output, only logs and deletion messages
+	FormatJSON  Format = "json"  // an indented JSON document
+	FormatYAML  Format = "yaml"  // a YAML document
+	FormatCSV   Format = "csv"   // one CSV row per resource, with a header
+	FormatTable Format = "table" // one aligned row per resource, with a header
+)
+
+// ParseFormat converts a string into a Format. It returns an error if the
+// string is not a known format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatText, FormatJSON, FormatYAML, FormatCSV, FormatTable:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q: expected one of text, json, yaml, csv or table", s)
+	}
+}
+
+// header is the list of columns used by the CSV and table formats.
+var header = []string{"PROVIDER", "REGION", "TYPE", "ID", "NAME", "SELECTED", "ACTION", "REASON"}
+
+// summaryHeader is the list of columns used by the summary table, and by
+// the summary section of the CSV and table formats.
+var summaryHeader = []string{"TYPE", "FOUND", "FILTERED", "PROTECTED", "REFUSED", "DELETED", "PENDING", "FAILED"}
+
+// columns returns the values of a record in the same order as the header.
+func (r Record) columns() []string {
+	return []string{r.Provider, r.Region, r.Type, r.ID, r.Name, strconv.FormatBool(r.Selected), string(r.Action), r.Reason}
+}
+
+// Write encodes the report into the given writer using the given format.
+// The CSV and table formats write the summary as a second section, with its
+// own header, after an empty line. The text format writes nothing.
+func Write(w io.Writer, format Format, rep *Report) error {
+	switch format {
+	case FormatText:
+		return nil
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(rep); err != nil {
+			return fmt.Errorf("unable to encode report as JSON: %w", err)
+		}
+	case FormatYAML:
+		if err := yaml.NewEncoder(w).Encode(rep); err != nil {
+			return fmt.Errorf("unable to encode report as YAML: %w", err)
+		}
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return fmt.Errorf("unable to write CSV header: %w", err)
+		}
+
+		for _, record := range rep.Resources {
+			if err := cw.Write(record.columns()); err != nil {
+				return fmt.Errorf("unable to write CSV record: %w", err)
+			}
+		}
+
+		cw.Flush()
+		fmt.Fprintln(w)
+
+		if err := cw.Write(summaryHeader); err != nil {
+			return fmt.Errorf("unable to write CSV summary header: %w", err)
+		}
+
+		for _, summary := range rep.Summary {
+			if err := cw.Write(summary.columns()); err != nil {
+				return fmt.Errorf("unable to write CSV summary: %w", err)
+			}
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("unable to write CSV: %w", err)
+		}
+	case FormatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+		for _, record := range rep.Resources {
+			fmt.Fprintln(tw, strings.Join(record.columns(), "\t"))
+		}
+
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("unable to write table: %w", err)
+		}
+
+		fmt.Fprintln(w)
+
+		if err := WriteSummary(w, rep.Summary); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+
+	return nil
+}
+
+// WriteSummary writes the summaries into the given writer as an aligned
+// table, with a header.
+func WriteSummary(w io.Writer, summaries []Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(summaryHeader, "\t"))
+
+	for _, summary := range summaries {
+		fmt.Fprintln(tw, strings.Join(summary.columns(), "\t"))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("unable to write summary table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func testReport() *Report {
+	r := NewRecorder()
+	r.Add(Record{Provider: "civo", Region: "lon1", Type: "instance", ID: "1", Name: "ci-1", Selected: true, Action: ActionDeleted})
+	r.Add(Record{Provider: "civo", Region: "lon1", Type: "network", ID: "2", Name: "default", Action: ActionSkipped, Reason: "it is the default network"})
+
+	return r.Report()
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatJSON, testReport()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got Report
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("unable to decode JSON output: %v", err)
+		}
+
+		if len(got.Resources) != 2 || got.Resources[1].Reason != "it is the default network" || len(got.Summary) != 3 {
+			t.Fatalf("unexpected JSON output: %s", buf.String())
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatYAML, testReport()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got Report
+		if err := yaml.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("unable to decode YAML output: %v", err)
+		}
+
+		if len(got.Resources) != 2 || got.Resources[0].Action != ActionDeleted {
+			t.Fatalf("unexpected YAML output: %s", buf.String())
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatCSV, testReport()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := "PROVIDER,REGION,TYPE,ID,NAME,SELECTED,ACTION,REASON\n" +
+			"civo,lon1,instance,1,ci-1,true,deleted,\n" +
+			"civo,lon1,network,2,default,false,skipped,it is the default network\n" +
+			"\n" +
+			"TYPE,FOUND,FILTERED,PROTECTED,REFUSED,DELETED,PENDING,FAILED\n" +
+			"instance,1,0,0,0,1,0,0\n" +
+			"network,1,1,0,0,0,0,0\n" +
+			"total,2,1,0,0,1,0,0\n"
+		if buf.String() != want {
+			t.Fatalf("expecting CSV output:\n%s\ngot:\n%s", want, buf.String())
+		}
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatTable, testReport()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 8 || !strings.HasPrefix(lines[0], "PROVIDER") || !strings.HasPrefix(lines[4], "TYPE") {
+			t.Fatalf("unexpected table output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("text writes nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, FormatText, testReport()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if buf.Len() != 0 {
+			t.Fatalf("expecting no output, got %q", buf.String())
+		}
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	records := []Record{
+		{Type: "instance", Action: ActionDeleted},
+		{Type: "volume", Action: ActionRefused},
+		{Type: "instance", Action: ActionFailed},
+		{Type: "instance", Action: ActionProtected},
+		{Type: "volume", Action: ActionSkipped},
+		{Type: "volume", Action: ActionPlanned},
+		{Type: "volume", Action: ActionPending},
+	}
+
+	want := []Summary{
+		{Type: "instance", Found: 3, Protected: 1, Deleted: 1, Failed: 1},
+		{Type: "volume", Found: 4, Filtered: 1, Refused: 1, Pending: 1},
+		{Type: "total", Found: 7, Filtered: 1, Protected: 1, Refused: 1, Deleted: 1, Pending: 1, Failed: 1},
+	}
+
+	got := Summarize(records)
+	if len(got) != len(want) {
+		t.Fatalf("expecting %d summaries, got %v", len(want), got)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expecting summary %d to be %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	t.Run("no records", func(t *testing.T) {
+		got := Summarize(nil)
+		if len(got) != 1 || got[0] != (Summary{Type: "total"}) {
+			t.Fatalf("expecting only an empty total, got %v", got)
+		}
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("JSON"); err != nil || f != FormatJSON {
+		t.Fatalf("expecting json, got %q (error: %v)", f, err)
+	}
+
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatalf("expecting an error for an unknown format")
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	var nilRecorder *Recorder
+	nilRecorder.Add(Record{}) // must not panic
+
+	r := NewRecorder()
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Add(Record{Provider: "civo"})
+		}()
+	}
+	wg.Wait()
+
+	if got := len(r.Report().Resources); got != 50 {
+		t.Fatalf("expecting 50 records, got %d", got)
+	}
+}
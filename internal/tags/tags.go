@@ -0,0 +1,148 @@
+package tags
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Split splits a tag into its key and value. Cloud providers store tags as
+// plain strings, so "key=value" and "key:value" are both accepted; a tag
+// without a separator has an empty value.
+func Split(tag string) (string, string) {
+	i := strings.IndexAny(tag, "=:")
+	if i < 0 {
+		return strings.TrimSpace(tag), ""
+	}
+
+	return strings.TrimSpace(tag[:i]), strings.TrimSpace(tag[i+1:])
+}
+
+// Lookup returns the value of the first tag with the given key, ignoring
+// case, and whether it was found.
+func Lookup(tags []string, key string) (string, bool) {
+	for _, tag := range tags {
+		if k, v := Split(tag); strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// selector matches tags by key and, optionally, by value.
+type selector struct {
+	key      string
+	value    string
+	anyValue bool
+}
+
+// String returns the selector as it was written by the user.
+func (s selector) String() string {
+	if s.anyValue {
+		return s.key
+	}
+
+	return s.key + "=" + s.value
+}
+
+// matches checks if any of the tags is selected by the selector. Keys are
+// compared ignoring case, values are compared exactly.
+func (s selector) matches(tags []string) bool {
+	return slices.ContainsFunc(tags, func(tag string) bool {
+		k, v := Split(tag)
+		return strings.EqualFold(k, s.key) && (s.anyValue || v == s.value)
+	})
+}
+
+// parseSelector parses a "key=value" or "key" selector.
+func parseSelector(s string) (selector, error) {
+	key, value := Split(s)
+	if key == "" {
+		return selector{}, fmt.Errorf("invalid tag %q: the key must not be empty", s)
+	}
+
+	return selector{key: key, value: value, anyValue: !strings.ContainsAny(s, "=:")}, nil
+}
+
+// Filter selects resources based on their tags. A nil Filter, or one
+// without selectors, selects every resource.
+type Filter struct {
+	with    []selector
+	without []selector
+}
+
+// NewFilter creates a Filter selecting resources having every tag in with
+// and none of the tags in without. Each tag is either "key=value", to match
+// a specific value, or "key", to match any value. It returns an error if a
+// tag has an empty key.
+func NewFilter(with, without []string) (*Filter, error) {
+	f := &Filter{}
+
+	for _, s := range with {
+		sel, err := parseSelector(s)
+		if err != nil {
+			return nil, err
+		}
+
+		f.with = append(f.with, sel)
+	}
+
+	for _, s := range without {
+		sel, err := parseSelector(s)
+		if err != nil {
+			return nil, err
+		}
+
+		f.without = append(f.without, sel)
+	}
+
+	return f, nil
+}
+
+// Match checks if a resource with the given tags is selected. Resources
+// whose type doesn't support tags are never selected when tags are
+// required. If the resource isn't selected, it also returns the reason why,
+// which can be used in log messages.
+func (f *Filter) Match(tags []string, taggable bool) (bool, string) {
+	if f == nil {
+		return true, ""
+	}
+
+	if len(f.with) > 0 && !taggable {
+		return false, "its type doesn't support tags"
+	}
+
+	for _, sel := range f.without {
+		if sel.matches(tags) {
+			return false, fmt.Sprintf("it has the excluded tag %q", sel)
+		}
+	}
+
+	for _, sel := range f.with {
+		if !sel.matches(tags) {
+			return false, fmt.Sprintf("it doesn't have the tag %q", sel)
+		}
+	}
+
+	return true, ""
+}
+
+// String returns a description of the filter, or an empty string if it
+// selects every resource.
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, sel := range f.with {
+		parts = append(parts, fmt.Sprintf("tag %q", sel))
+	}
+
+	for _, sel := range f.without {
+		parts = append(parts, fmt.Sprintf("without tag %q", sel))
+	}
+
+	return strings.Join(parts, " and ")
+}
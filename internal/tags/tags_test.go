@@ -0,0 +1,79 @@
+package tags
+
+import "testing"
+
+func TestSplit(t *testing.T) {
+	cases := []struct {
+		tag, key, value string
+	}{
+		{tag: "env=ci", key: "env", value: "ci"},
+		{tag: "env:ci", key: "env", value: "ci"},
+		{tag: "ephemeral", key: "ephemeral"},
+		{tag: "dropkick-expires=2026-10-20T00:00Z", key: "dropkick-expires", value: "2026-10-20T00:00Z"},
+		{tag: "dropkick-expires:2026-10-20T00:00Z", key: "dropkick-expires", value: "2026-10-20T00:00Z"},
+	}
+
+	for _, tc := range cases {
+		key, value := Split(tc.tag)
+		if key != tc.key || value != tc.value {
+			t.Fatalf("expecting %q to split into %q and %q, got %q and %q", tc.tag, tc.key, tc.value, key, value)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	value, ok := Lookup([]string{"env=ci", "Dropkick-TTL=4h"}, "dropkick-ttl")
+	if !ok || value != "4h" {
+		t.Fatalf("expecting to find %q, got %q (found: %v)", "4h", value, ok)
+	}
+
+	if _, ok := Lookup([]string{"env=ci"}, "dropkick-ttl"); ok {
+		t.Fatalf("expecting the tag not to be found")
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	cases := []struct {
+		name     string
+		with     []string
+		without  []string
+		tags     []string
+		taggable bool
+		want     bool
+	}{
+		{name: "no selectors", tags: nil, taggable: false, want: true},
+		{name: "key and value", with: []string{"env=ci"}, tags: []string{"env=ci"}, taggable: true, want: true},
+		{name: "colon separator", with: []string{"env=ci"}, tags: []string{"env:ci"}, taggable: true, want: true},
+		{name: "wrong value", with: []string{"env=ci"}, tags: []string{"env=prod"}, taggable: true, want: false},
+		{name: "key only", with: []string{"ephemeral"}, tags: []string{"ephemeral=yes"}, taggable: true, want: true},
+		{name: "every tag is required", with: []string{"env=ci", "team=infra"}, tags: []string{"env=ci"}, taggable: true, want: false},
+		{name: "without tag", without: []string{"keep"}, tags: []string{"env=ci", "keep"}, taggable: true, want: false},
+		{name: "without tag absent", without: []string{"keep"}, tags: []string{"env=ci"}, taggable: true, want: true},
+		{name: "not taggable with tags required", with: []string{"env=ci"}, taggable: false, want: false},
+		{name: "not taggable without tags excluded", without: []string{"keep"}, taggable: false, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewFilter(tc.with, tc.without)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, reason := f.Match(tc.tags, tc.taggable)
+			if got != tc.want {
+				t.Fatalf("expecting match to be %v, got %v (reason: %q)", tc.want, got, reason)
+			}
+
+			if !got && reason == "" {
+				t.Fatalf("expecting a reason when the resource isn't selected")
+			}
+		})
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	if _, err := NewFilter([]string{"=ci"}, nil); err == nil {
+		t.Fatalf("expecting an error for an empty key")
+	}
+}